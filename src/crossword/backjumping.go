@@ -0,0 +1,56 @@
+package crossword
+
+import (
+	"github.com/nitzanhen/crossword/src/structure"
+)
+
+// Returns the cut extended by one cell at each end, i.e. together with the cells that bound it.
+func extend(cut Cut) Cut {
+	row, col := Move(cut.Row, cut.Col, cut.Orientation, -1)
+
+	return Cut{row, col, cut.Orientation, cut.Len + 2}
+}
+
+// Checks and returns whether the two cuts share any cell.
+func doCutsOverlap(cut, other Cut) bool {
+	for k := 0; k < cut.Len; k++ {
+		if i, j := Move(cut.Row, cut.Col, cut.Orientation, k); IsInCut(i, j, other) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the indices (in Embeddings) of the embeddings that limit which words may fill cut:
+// those that wrote a letter or a stop into the cut or right next to it, and those whose word would fit in it
+// if it were not already embedded.
+func (cw *Crossword) culpritsOf(cut Cut) structure.Set[int] {
+	culprits := structure.NewSet[int](0)
+	data := cw.GetCutData(cut)
+
+	for i, cutword := range cw.Embeddings {
+		if doCutsOverlap(extend(cutword.Cut), extend(cut)) || FitsIn(data, cutword.Word, cw.Empty) {
+			culprits.Add(i)
+		}
+	}
+
+	return culprits
+}
+
+// Checks and returns whether any of the culprits was embedded at or after the given level,
+// i.e. whether the conflict they explain may be resolved by changing the embedding made at that level.
+func isCulpritFrom(culprits structure.Set[int], level int) bool {
+	for _, i := range culprits.ToSlice() {
+		if i >= level {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the culprits embedded before the given level.
+func culpritsBefore(culprits structure.Set[int], level int) *structure.Set[int] {
+	return culprits.Copy().Filter(func(i int) bool { return i < level })
+}
@@ -1,29 +1,155 @@
 package crossword
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math/rand"
 	"regexp"
 	"sort"
 	"strings"
-	"time"
+	"sync"
 
 	"github.com/nitzanhen/crossword/src/structure"
 )
 
+// Returned by BuildContext when the search space was exhausted without completing the crossword.
+// This doesn't prove that no crossword exists: a cut no word matches is given up on,
+// even where a stop placed later could have split it into cuts that words do match (see SetComplete).
+// The error returned is a *NoSolutionError, which matches ErrNoSolution under errors.Is.
+var ErrNoSolution = errors.New("no crossword could be built from the given words")
+
+type NoSolutionError struct {
+	// Whether the candidate cap left some words untried, in which case a crossword may exist nonetheless.
+	Capped bool
+}
+
+func (err *NoSolutionError) Error() string {
+	if err.Capped {
+		return ErrNoSolution.Error() + " (search limited by the candidate cap)"
+	}
+
+	return ErrNoSolution.Error()
+}
+
+func (err *NoSolutionError) Is(target error) bool {
+	return target == ErrNoSolution
+}
+
+// The default number of words the builder tries per cut.
+const DEFAULT_CANDIDATE_CAP = 100
+
 type Builder struct {
 	width, height int
-	corpus        Corpus
-
-	debug    bool
-	Calls    int
-	Failures int
-	start    time.Time
+	corpus        *Corpus
+	// The partially filled crossword builds start from, or nil for an empty one (see SetGrid),
+	// and the direction of the empty one.
+	grid      *Crossword
+	direction Direction
+	// The words every crossword must contain, and those of them the builder places itself, longest first.
+	themeWords []ThemeWord
+	freeThemes []Word
+	// The fixed stops of the grid, if any, and the symmetry its stops must keep.
+	template [][]bool
+	symmetry Symmetry
+	// Quality constraints on every crossword (see meetsQuality).
+	minWordLength int
+	maxStopShare  float64
+	connected     bool
+	maxUnchecked  int
+
+	debug bool
+	stats buildStats
+
+	// The maximum number of words tried per cut (<= 0 for all), and whether it left words out in the current build.
+	candidateCap int
+	capped       bool
+	// Search exhaustively, so that a failure proves there is no crossword with the current cut structure.
+	complete bool
+	// Set while enumerating crosswords: called with each one completed, returns false to stop the search.
+	yield func(cw *Crossword) bool
+
+	// Match cuts with regular expressions over the whole corpus, instead of using its index.
+	regexMatching bool
+	// Prune candidates by arc consistency between crossing cuts before each step.
+	propagation bool
+	// Skip over levels of the search that are not to blame for a failure below them.
+	backjumping bool
+
+	// Restarts the search once an attempt has visited as many states as the schedule allows.
+	restarts  RestartSchedule
+	callLimit int64 // The calls count at which the current attempt ends, or 0 if it never does.
+
+	// The maximum number of failed states to remember, and the table remembering them during a build.
+	nogoodCapacity int
+	nogoods        *nogoodTable
+	// The number of times the current build left part of a subtree unsearched, e.g. words beyond the candidate cap.
+	// Only failures of subtrees searched exhaustively are remembered, as a later attempt may search the rest.
+	unsearched int
+
+	// The heuristics deciding which cut to fill next, and which of its words to try first.
+	cutOrder  CutOrder
+	wordOrder WordOrder
+
+	// Randomizes tie-breaking between cuts and words; nil keeps the search deterministic in corpus order.
+	rng    *rand.Rand
+	seed   int64
+	seeded bool
 
 	listener *func(cw *Crossword)
+
+	// The searches run by BuildParallel, whose stats are reported as this builder's.
+	mu      sync.Mutex
+	workers []*Builder
 }
 
-func NewBuilder(width, height int, words []Word, debug bool) Builder {
-	return Builder{width, height, NewCorpus(words), debug, 0, 0, time.Time{}, nil}
+func NewBuilder(width, height int, words []Word, debug bool) *Builder {
+	return NewBuilderWithCorpus(width, height, NewCorpus(words), debug)
+}
+
+// Creates a builder that searches the given corpus, which may be shared with other builders.
+func NewBuilderWithCorpus(width, height int, corpus *Corpus, debug bool) *Builder {
+	return &Builder{
+		width:  width,
+		height: height,
+		corpus: corpus,
+		debug:  debug,
+
+		cutOrder:     MRV{},
+		wordOrder:    CorpusOrder{},
+		candidateCap: DEFAULT_CANDIDATE_CAP,
+
+		minWordLength: DEFAULT_MIN_WORD_LENGTH,
+		maxStopShare:  1,
+		maxUnchecked:  -1,
+
+		propagation:    true,
+		backjumping:    true,
+		nogoodCapacity: DEFAULT_NOGOOD_CAPACITY,
+	}
+}
+
+// Returns a snapshot of the current (or last) build's statistics.
+// Safe to call from any goroutine, including while a build is running.
+// While BuildParallel runs, the stats of all of its searches are merged.
+//
+// The cache hit rate counts the corpus lookups made since the build began. A corpus shared with other builders
+// counts their lookups too, if they run at the same time.
+func (builder *Builder) Stats() Stats {
+	cells := builder.width * builder.height
+	stats := builder.stats.snapshot(cells)
+
+	builder.mu.Lock()
+	workers := builder.workers
+	builder.mu.Unlock()
+
+	for _, worker := range workers {
+		stats.merge(worker.stats.snapshot(cells))
+	}
+
+	stats.CacheHitRate = builder.stats.cacheHitRate(builder.corpus)
+
+	return stats
 }
 
 func (builder *Builder) getExactCutRegex(cutData []string) regexp.Regexp {
@@ -74,13 +200,19 @@ func (builder *Builder) getGracefulCutRegex(cutData []string) regexp.Regexp {
 
 func (builder *Builder) getMatchingWords(cw *Crossword, cut Cut) []Word {
 	cutData := cw.GetCutData(cut)
-	regex := builder.getGracefulCutRegex(cutData)
 
-	matches := builder.corpus.Filter(regex)
+	var matches []Word
+	if builder.regexMatching {
+		matches = builder.corpus.Filter(builder.getGracefulCutRegex(cutData))
+	} else {
+		matches = builder.corpus.Match(cutData, cw.Empty)
+	}
 
 	return Filter(
 		matches,
-		func(w Word) bool { return !cw.IsWordEmbedded(w) },
+		func(w Word) bool {
+			return !cw.IsWordEmbedded(w) && len(builder.corpus.charsOf(w)) >= builder.minWordLength
+		},
 	)
 }
 
@@ -108,12 +240,19 @@ func (builder *Builder) isValidOffset(
 		}
 	}
 
-	// Test Regex for string
+	if !builder.allowsPlacement(cw, subcut) {
+		return false
+	}
+
+	// Test the word against the subcut's letters
 
 	data := cw.GetCutData(subcut)
-	regex := builder.getExactCutRegex(data)
+	if builder.regexMatching {
+		regex := builder.getExactCutRegex(data)
+		return regex.MatchString(string(word))
+	}
 
-	return regex.MatchString(string(word))
+	return Fits(data, word, cw.Empty)
 }
 
 // func (builder *Builder) debugBuild(word Word, cw *Crossword) {
@@ -128,50 +267,171 @@ func (builder *Builder) isValidOffset(
 // 	)
 // }
 
-// Attempts to find a suitable crossword with the given cuts embedded
-func (builder *Builder) build(cw *Crossword, cuts structure.Set[Cut]) *Crossword {
-	builder.Calls++
+// Attempts to find a suitable crossword with the given cuts embedded, like search,
+// but first consults the no-good table and records the state there if it fails after an exhaustive search.
+func (builder *Builder) build(
+	ctx context.Context,
+	cw *Crossword,
+	cuts structure.Set[Cut],
+) (*Crossword, structure.Set[int], error) {
+	if builder.nogoods == nil {
+		return builder.search(ctx, cw, cuts)
+	}
+
+	key := nogoodKey(cw, cuts)
+	if conflict, ok := builder.nogoods.get(cw, key); ok {
+		builder.stats.nogoodHits.Add(1)
+		return nil, conflict, nil
+	}
+
+	unsearched := builder.unsearched
+	result, conflict, err := builder.search(ctx, cw, cuts)
+	if result == nil && err == nil && builder.unsearched == unsearched {
+		builder.nogoods.add(cw, key, conflict)
+		builder.stats.nogoods.Store(int64(builder.nogoods.size()))
+	}
+
+	return result, conflict, err
+}
+
+// Attempts to find a suitable crossword with the given cuts embedded.
+// Returns a nil crossword if there is none, and an error if the search was interrupted by ctx
+// or an embedding could not be written to the grid.
+//
+// On failure, also returns the conflict set: the indices of the embeddings that caused it (see culpritsOf).
+// A state whose conflict set holds none of the embeddings made since some level of the search
+// fails regardless of what was embedded at that level, so that level need not try any other embedding.
+func (builder *Builder) search(
+	ctx context.Context,
+	cw *Crossword,
+	cuts structure.Set[Cut],
+) (*Crossword, structure.Set[int], error) {
+	conflict := structure.NewSet[int](0)
+
+	if err := ctx.Err(); err != nil {
+		return nil, conflict, err
+	}
+
+	calls := builder.stats.calls.Add(1)
+	if builder.callLimit > 0 && calls > builder.callLimit {
+		return nil, conflict, errRestart
+	}
+
+	builder.stats.depth.Add(1)
+	defer builder.stats.depth.Add(-1)
+
+	if !builder.meetsQuality(cw, cuts.Size() == 0) {
+		// The whole grid is to blame
+		builder.stats.failures.Add(1)
+		return nil, structure.SetFromSlice(IndexArray(len(cw.Embeddings))), nil
+	}
+
+	if word, ok := builder.pendingThemeWord(cw); ok {
+		// Theme words are placed before any other
+		return builder.searchThemeWord(ctx, cw, cuts, word)
+	}
 
 	if cuts.Size() == 0 {
 		// Crossword is complete
-		return cw
+		if builder.yield == nil {
+			return cw, conflict, nil
+		}
+
+		// Enumerating - report the crossword and keep searching
+		if !builder.yield(cw) {
+			return nil, conflict, errStop
+		}
+
+		// This is no failure, so no earlier level may be skipped on its account: blame every embedding
+		return nil, structure.SetFromSlice(IndexArray(len(cw.Embeddings))), nil
 	}
 
+	// The embedding made at this level will be the next one
+	level := len(cw.Embeddings)
+
 	// Check for inconsistencies
 
 	cutMatchMap := structure.NewOrderedMap[Cut, []Word](cuts.Size())
+	reasons := structure.NewOrderedMap[Cut, structure.Set[int]](cuts.Size())
 	for _, cut := range cuts.ToSlice() {
 		matches := builder.getMatchingWords(cw, cut)
+		reasons.Set(cut, cw.culpritsOf(cut))
 
 		if len(matches) == 0 {
 			// We have a cut with no matches.
 			// It's an inconsistency, stop here.
 
-			builder.Failures++
-			return nil
+			builder.stats.failures.Add(1)
+			return nil, reasons.Get(cut), nil
 		}
 
 		cutMatchMap.Set(cut, matches)
 	}
 
-	cutMatches := cutMatchMap.Entries()
-	sort.Slice(cutMatches, func(i, j int) bool {
-		return len(cutMatches[i].Value) < len(cutMatches[j].Value)
-	})
+	// Narrow down the matches to placements consistent with every crossing cut
+	var domains *structure.OrderedMap[Cut, []placement]
+	if builder.propagation && !builder.isExhaustive() {
+		domains = structure.NewOrderedMap[Cut, []placement](cuts.Size())
+		for _, entry := range cutMatchMap.Entries() {
+			domains.Set(entry.Key, builder.getPlacements(cw, entry.Key, entry.Value))
+		}
+
+		if cut, ok := builder.propagate(cw, domains, reasons); !ok {
+			builder.stats.failures.Add(1)
+			return nil, reasons.Get(cut), nil
+		}
+
+		for _, entry := range domains.Entries() {
+			cutMatchMap.Set(entry.Key, distinctWords(entry.Value))
+		}
+	}
+
+	state := &SearchState{Crossword: cw, Corpus: builder.corpus, Rand: builder.rng, cuts: cuts}
+
+	cutMatches := Map(
+		cutMatchMap.Entries(),
+		func(entry structure.MapEntry[Cut, []Word]) Candidates { return Candidates{entry.Key, entry.Value} },
+	)
+	if builder.rng != nil {
+		// Break ties between cuts randomly
+		builder.rng.Shuffle(len(cutMatches), func(i, j int) {
+			cutMatches[i], cutMatches[j] = cutMatches[j], cutMatches[i]
+		})
+	}
+	builder.cutOrder.OrderCuts(state, cutMatches)
 
 	// Find a suitable next embedding
 	for _, entry := range cutMatches {
-		cut, matches := entry.Key, entry.Value
-		if len(matches) > 100 {
-			matches = matches[:100]
+		cut, matches := entry.Cut, entry.Words
+
+		// Whatever limited the cut's candidates is also to blame if none of them works out
+		cutReasons := reasons.Get(cut)
+		conflict.Union(&cutReasons)
+
+		if builder.rng != nil {
+			// Break ties between words randomly
+			builder.rng.Shuffle(len(matches), func(i, j int) {
+				matches[i], matches[j] = matches[j], matches[i]
+			})
+		}
+		builder.wordOrder.OrderWords(state, cut, matches)
+		if cap := builder.candidateCap; !builder.isExhaustive() && cap > 0 && len(matches) > cap {
+			matches = matches[:cap]
+			builder.capped = true
+			builder.unsearched++
 		}
 		for _, word := range matches {
 
 			// Get valid offsets
-			maxOffset := cut.Len - len([]rune(word)) + 1
-			validOffsets := Filter(IndexArray(maxOffset), func(offset int) bool {
-				return builder.isValidOffset(cw, cut, word, offset)
-			})
+			var validOffsets []int
+			if domains != nil {
+				validOffsets = offsetsOf(domains.Get(cut), word)
+			} else {
+				maxOffset := cut.Len - len([]rune(word)) + 1
+				validOffsets = Filter(IndexArray(maxOffset), func(offset int) bool {
+					return builder.isValidOffset(cw, cut, word, offset)
+				})
+			}
 
 		EmbeddingLoop:
 			for _, offset := range validOffsets {
@@ -179,58 +439,275 @@ func (builder *Builder) build(cw *Crossword, cuts structure.Set[Cut]) *Crossword
 
 				subcut := cw.Subcut(cut, offset, offset+len([]rune(word)))
 
-				next.Embed(subcut, word)
+				if err := next.Embed(subcut, word); err != nil {
+					return nil, conflict, fmt.Errorf("embedding %v in %v: %w", word, subcut.String(), err)
+				}
+				if err := builder.mirrorStops(&next); err != nil {
+					return nil, conflict, err
+				}
+				builder.stats.fill(next.CountFilled())
 
-				subcuts := next.SubcutsOf(
+				subcuts := builder.subcutsOf(
+					&next,
 					cuts.ToSlice(),
 				)
 				nextCuts := structure.SetFromSlice(subcuts)
 				nextCuts.Delete(subcut)
 
-				components := GetCutGraph(&next, &nextCuts).Components()
-				if len(components) > 1 {
-					sort.Slice(components, func(i, j int) bool {
-						return components[i].Size() < components[j].Size()
-					})
+				var components []structure.Set[Cut]
+				if builder.isExhaustive() || builder.hasQualityConstraints() || builder.symmetry != NO_SYMMETRY {
+					// Components are independent but for words being used only once, so a failing component
+					// may be completable given a different fill of an earlier one - which solving the components
+					// one after the other never tries. Solve them together instead.
+					// Quality constraints span the whole grid, so they can only be checked once all of it is filled,
+					// and the stops a symmetry mirrors split the cuts of other components, which must then be split anew.
+					components = []structure.Set[Cut]{nextCuts}
+				} else {
+					components = GetCutGraph(&next, &nextCuts).Components()
+					if len(components) > 1 {
+						sort.Slice(components, func(i, j int) bool {
+							return components[i].Size() < components[j].Size()
+						})
+					}
 				}
 
-				if builder.listener != nil && builder.Calls%2_000 == 0 {
+				if builder.listener != nil && calls%2_000 == 0 {
 					//builder.debugBuild(word, &next)
 					(*builder.listener)(&next)
 				}
 
 				// Try filling in each of the components
 				result := &next
-				for _, component := range components {
-					result = builder.build(result, component)
+				for i, component := range components {
+					var err error
+					var childConflict structure.Set[int]
+					result, childConflict, err = builder.build(ctx, result, component)
 
+					if err != nil {
+						return nil, conflict, err
+					}
 					if result == nil {
-						// One of the components cant be completed - try the next embedding
+						// One of the components cant be completed
+						builder.stats.backtracks.Add(1)
+						if i > 0 {
+							// ...given the fill of the ones before it, and no other fill of those is tried
+							builder.unsearched++
+						}
+
+						// Symmetric stops are not attributed to the embeddings that placed them (see culpritsOf)
+						backjumping := builder.backjumping && builder.symmetry == NO_SYMMETRY
+						if backjumping && !isCulpritFrom(childConflict, level) {
+							// Nothing embedded at this level or below is to blame,
+							// so no other embedding here can help - jump back to the most recent culprit.
+							builder.stats.failures.Add(1)
+							builder.stats.backjumps.Add(1)
+							return nil, childConflict, nil
+						}
+
+						// Try the next embedding
+						conflict.Union(culpritsBefore(childConflict, level))
 						continue EmbeddingLoop
 					}
 
 				}
 
 				// Result is non nil, we've completed the embedding
-				return result
+				return result, conflict, nil
 			}
 		}
 	}
 
 	// No suitable embedding found
-	builder.Failures++
-	return nil
+	builder.stats.failures.Add(1)
+	return nil, conflict, nil
 }
 
+// Builds a crossword, returning nil if none could be built.
 func (builder *Builder) Build() *Crossword {
-	cw := NewCrossword(builder.width, builder.height)
-	cuts := structure.SetFromSlice(cw.GetCuts())
+	cw, _ := builder.BuildContext(context.Background())
+
+	return cw
+}
+
+// Builds a crossword, aborting the search as soon as ctx is done.
+// In that case the context's error (e.g. context.DeadlineExceeded) is returned;
+// if the search completes without a result, a *NoSolutionError (matching ErrNoSolution) is returned.
+func (builder *Builder) BuildContext(ctx context.Context) (*Crossword, error) {
+	cw, cuts, err := builder.start(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	builder.begin()
+	defer builder.stats.finish()
+
+	rng := builder.rng
+
+	for attempt := 0; ; attempt++ {
+		builder.callLimit = 0
+		if builder.restarts != nil {
+			builder.callLimit = builder.stats.calls.Load() + int64(builder.restarts(attempt))
+		}
+
+		unsearched := builder.unsearched
+		result, _, err := builder.build(ctx, &cw, cuts)
 
-	builder.start = time.Now()
+		// An attempt that failed without searching everything, e.g. for the candidate cap, may succeed in another order
+		incomplete := result == nil && err == nil && builder.unsearched != unsearched
+		if errors.Is(err, errRestart) || (builder.restarts != nil && incomplete) {
+			builder.stats.restarts.Add(1)
 
-	return builder.build(&cw, cuts)
+			if builder.rng == nil {
+				// The order is otherwise deterministic - perturb it, so that the next attempt searches differently.
+				// Failed states are kept in the no-good table, so they aren't searched again; only those searched
+				// exhaustively are there, so that the next attempt may search the rest of the others.
+				builder.rng = rand.New(rand.NewSource(int64(attempt)))
+			}
+			continue
+		}
+
+		builder.rng = rng
+
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			return nil, &NoSolutionError{builder.capped}
+		}
+
+		return result, nil
+	}
 }
 
+// Resets the builder's per-build state, before a build.
+func (builder *Builder) begin() {
+	if builder.seeded {
+		// Reseed so that every build with the same seed searches in the same order
+		builder.rng = rand.New(rand.NewSource(builder.seed))
+	}
+
+	builder.nogoods = nil
+	if builder.nogoodCapacity > 0 {
+		builder.nogoods = newNogoodTable(builder.nogoodCapacity)
+	}
+
+	builder.capped = false
+	builder.unsearched = 0
+	builder.callLimit = 0
+
+	// The searches of an earlier BuildParallel are no longer this build's
+	builder.mu.Lock()
+	builder.workers = nil
+	builder.mu.Unlock()
+
+	builder.stats.reset(builder.corpus)
+}
+
+// Checks and returns whether the current build must search exhaustively (see SetComplete).
+func (builder *Builder) isExhaustive() bool {
+	return builder.complete || builder.yield != nil
+}
+
+// Sets the heuristic deciding which cut the builder tries to fill next. Defaults to MRV.
+func (builder *Builder) SetCutOrder(order CutOrder) {
+	builder.cutOrder = order
+}
+
+// Sets the heuristic deciding the order in which the builder tries a cut's words. Defaults to CorpusOrder.
+func (builder *Builder) SetWordOrder(order WordOrder) {
+	builder.wordOrder = order
+}
+
+// Sets the maximum number of words the builder tries in each cut, after ordering them. A cap <= 0 removes the limit.
+// Capping speeds up the search, but makes it incomplete: a failed build may have left out the words
+// that would have completed the crossword. NoSolutionError.Capped tells whether this may be the case.
+func (builder *Builder) SetCandidateCap(cap int) {
+	builder.candidateCap = cap
+}
+
+// Makes the builder search exhaustively: every word is tried in every cut, with no candidate cap,
+// separate regions of the grid are filled jointly rather than one by one, and no propagation is done.
+// A failed complete build proves only that no crossword can be built with the current cut structure:
+// a cut no word matches fails the search, even if a stop that a later embedding would place could split it
+// into cuts that words do match.
+// Complete builds may take much longer.
+func (builder *Builder) SetComplete(enabled bool) {
+	builder.complete = enabled
+}
+
+// Randomizes the order in which the builder tries cuts and words of equal rank.
+// Builds with the same seed and words always produce the same crossword.
+func (builder *Builder) SetSeed(seed int64) {
+	builder.rng = rand.New(rand.NewSource(seed))
+	builder.seed = seed
+	builder.seeded = true
+}
+
+// Like SetSeed, but draws from the given source.
+// Builds are only reproducible if the source is reset between them.
+func (builder *Builder) SetRandSource(source rand.Source) {
+	builder.rng = rand.New(source)
+	builder.seed = 0
+	builder.seeded = false
+}
+
+// Returns the seed passed to SetSeed, and whether there was one.
+func (builder *Builder) Seed() (int64, bool) {
+	return builder.seed, builder.seeded
+}
+
+// Makes the builder match cuts by compiling a regular expression for each and scanning the entire corpus,
+// rather than by intersecting the corpus' positional index. Both find the same words; the regex path is much slower,
+// and remains as a fallback and a reference to check the index against.
+func (builder *Builder) SetRegexMatching(enabled bool) {
+	builder.regexMatching = enabled
+}
+
+// Makes the builder enforce arc consistency between crossing cuts at every step of the search (see propagate).
+// Each step then costs more, but dead ends are detected many embeddings earlier; this pays off on larger grids.
+// Propagation assumes every cut eventually holds a word of its own, while a cut may also end up split into
+// single cells by crossing words; such crosswords are pruned. It is therefore skipped by complete searches.
+func (builder *Builder) SetPropagation(enabled bool) {
+	builder.propagation = enabled
+}
+
+// Makes the builder backjump (conflict-directed): when a state fails, the search returns straight to
+// the most recent embedding that contributed to the failure, rather than trying the alternatives of
+// every embedding in between, which would all fail the same way.
+func (builder *Builder) SetBackjumping(enabled bool) {
+	builder.backjumping = enabled
+}
+
+// Sets the maximum number of failed search states the builder remembers during a build,
+// so as not to search them again when reached through a different order of embeddings.
+// A capacity <= 0 disables this.
+func (builder *Builder) SetNogoodCapacity(capacity int) {
+	builder.nogoodCapacity = capacity
+}
+
+// Makes the builder abandon an attempt once it visits the number of states allowed by schedule,
+// and start again with a different (random) order of cuts and words. Run times of backtracking searches are
+// heavy-tailed, so restarting a long attempt is usually faster than waiting it out. A nil schedule disables restarts.
+//
+// An attempt that fails without searching every word, as the candidate cap makes it, also restarts,
+// so a capped build only fails once ctx is done, or once an attempt searched exhaustively.
+//
+// Restarted builds are still reproducible: the order of every attempt is determined by the builder's seed.
+func (builder *Builder) SetRestarts(schedule RestartSchedule) {
+	builder.restarts = schedule
+}
+
+// Makes the builder call listener with the crossword being searched, every so often during a build.
+// The searches of BuildParallel, and enumerations running alongside a build, share the listener;
+// their calls are serialized, so the listener never runs concurrently with itself and needn't be goroutine-safe.
 func (builder *Builder) SetListener(listener func(cw *Crossword)) {
-	builder.listener = &listener
+	var mu sync.Mutex
+	serialized := func(cw *Crossword) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		listener(cw)
+	}
+
+	builder.listener = &serialized
 }
@@ -0,0 +1,302 @@
+package crossword_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+)
+
+var squareWords = []crossword.Word{"ab", "cd", "ac", "bd"}
+
+func readWords(t *testing.T) []crossword.Word {
+	raw, err := os.ReadFile("../../words.json")
+	if err != nil {
+		t.Fatalf("Unable to read words: %v", err)
+	}
+
+	var words []crossword.Word
+	if err := json.Unmarshal(raw, &words); err != nil {
+		t.Fatalf("Unable to unmarshal words: %v", err)
+	}
+
+	return words
+}
+
+// Returns the words in an order determined by seed.
+func shuffleWords(words []crossword.Word, seed int64) []crossword.Word {
+	shuffled := make([]crossword.Word, len(words))
+	for i, j := range rand.New(rand.NewSource(seed)).Perm(len(words)) {
+		shuffled[j] = words[i]
+	}
+
+	return shuffled
+}
+
+func TestBuildContext(t *testing.T) {
+	builder := crossword.NewBuilder(2, 2, squareWords, false)
+
+	cw, err := builder.BuildContext(context.Background())
+	if err != nil {
+		t.Fatalf("Expected builder.BuildContext() to succeed, got %v", err)
+	}
+	if len(cw.Embeddings) != 4 {
+		t.Errorf("Expected 4 embeddings, got %d:\n%s", len(cw.Embeddings), cw.PrintData())
+	}
+
+	builder = crossword.NewBuilder(2, 2, []crossword.Word{"ab"}, false)
+	if _, err := builder.BuildContext(context.Background()); !errors.Is(err, crossword.ErrNoSolution) {
+		t.Errorf("Expected ErrNoSolution, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	builder = crossword.NewBuilder(2, 2, squareWords, false)
+	if cw, err := builder.BuildContext(ctx); cw != nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a cancelled build to return (nil, context.Canceled), got (%v, %v)", cw, err)
+	}
+}
+
+func TestStats(t *testing.T) {
+	builder := crossword.NewBuilder(2, 2, squareWords, false)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		builder.Build()
+	}()
+
+	// Polling while the build runs must be safe
+	for polling := true; polling; {
+		select {
+		case <-done:
+			polling = false
+		default:
+			builder.Stats()
+		}
+	}
+
+	stats := builder.Stats()
+	if stats.Calls == 0 {
+		t.Errorf("Expected stats.Calls > 0, got %d", stats.Calls)
+	}
+	if stats.BestFill != 1 {
+		t.Errorf("Expected stats.BestFill = 1, got %f", stats.BestFill)
+	}
+	if stats.Depth != 0 {
+		t.Errorf("Expected stats.Depth = 0 after the build, got %d", stats.Depth)
+	}
+	if stats.Elapsed <= 0 {
+		t.Errorf("Expected stats.Elapsed > 0, got %v", stats.Elapsed)
+	}
+
+	// The hit rate covers the build's own lookups, not those made on the corpus before it
+	corpus := crossword.NewCorpus(squareWords)
+	crossword.NewBuilderWithCorpus(2, 2, corpus, false).Build()
+
+	warm := crossword.NewBuilderWithCorpus(2, 2, corpus, false)
+	warm.Build()
+	if rate := warm.Stats().CacheHitRate; rate != 1 || corpus.HitRate() == 1 {
+		t.Errorf("Expected a build on a warm cache to only hit it, got %f (corpus %f)", rate, corpus.HitRate())
+	}
+}
+
+func TestSeed(t *testing.T) {
+	words := readWords(t)
+
+	build := func(seed int64) *crossword.Crossword {
+		builder := crossword.NewBuilder(4, 4, words, false)
+		builder.SetSeed(seed)
+
+		if got, ok := builder.Seed(); !ok || got != seed {
+			t.Errorf("Expected builder.Seed() = (%d, true), got (%d, %t)", seed, got, ok)
+		}
+
+		cw, err := builder.BuildContext(context.Background())
+		if err != nil {
+			t.Fatalf("Expected build with seed %d to succeed, got %v", seed, err)
+		}
+
+		return cw
+	}
+
+	first, second := build(7), build(7)
+	if first.PrintData() != second.PrintData() {
+		t.Errorf("Expected builds with the same seed to match, got\n%s\nand\n%s", first.PrintData(), second.PrintData())
+	}
+}
+
+func TestRegexMatching(t *testing.T) {
+	words := readWords(t)
+
+	build := func(regexMatching bool) *crossword.Crossword {
+		builder := crossword.NewBuilder(3, 3, words, false)
+		builder.SetSeed(3)
+		builder.SetRegexMatching(regexMatching)
+
+		return builder.Build()
+	}
+
+	indexed, regex := build(false), build(true)
+	if indexed == nil || regex == nil || indexed.PrintData() != regex.PrintData() {
+		t.Errorf("Expected index and regex matching to build the same crossword, got\n%v\nand\n%v", indexed, regex)
+	}
+}
+
+func TestPropagation(t *testing.T) {
+	// Rows can be filled, but no column (e.g. "cf?") can
+	words := []crossword.Word{"abc", "def", "ghi", "adg", "beh"}
+
+	calls := func(propagation bool) int {
+		builder := crossword.NewBuilder(3, 3, words, false)
+		builder.SetPropagation(propagation)
+
+		if cw := builder.Build(); cw != nil {
+			t.Fatalf("Expected no crossword to be built, got\n%s", cw.PrintData())
+		}
+
+		return builder.Stats().Calls
+	}
+
+	if withPropagation, without := calls(true), calls(false); withPropagation >= without {
+		t.Errorf("Expected propagation to visit fewer states, got %d with and %d without", withPropagation, without)
+	}
+
+	builder := crossword.NewBuilder(2, 2, squareWords, false)
+	builder.SetPropagation(true)
+	if cw := builder.Build(); cw == nil {
+		t.Errorf("Expected propagation to keep solvable grids solvable")
+	}
+}
+
+func TestBackjumping(t *testing.T) {
+	corpus := crossword.NewCorpus(shuffleWords(readWords(t), 2))
+
+	build := func(backjumping bool) (*crossword.Crossword, crossword.Stats) {
+		builder := crossword.NewBuilderWithCorpus(5, 5, corpus, false)
+		builder.SetBackjumping(backjumping)
+
+		return builder.Build(), builder.Stats()
+	}
+
+	cw, stats := build(true)
+	expected, expectedStats := build(false)
+
+	if cw == nil || expected == nil {
+		t.Fatalf("Expected both builds to succeed")
+	}
+
+	// Backjumping only skips states that cannot succeed, so the first crossword found is the same
+	if cw.PrintData() != expected.PrintData() {
+		t.Errorf("Expected backjumping to find\n%s\ngot\n%s", expected.PrintData(), cw.PrintData())
+	}
+	if stats.Backjumps == 0 || stats.Calls >= expectedStats.Calls {
+		t.Errorf("Expected backjumping to skip states, got %+v (without: %+v)", stats, expectedStats)
+	}
+}
+
+func TestNogoods(t *testing.T) {
+	corpus := crossword.NewCorpus(shuffleWords(readWords(t), 2))
+
+	build := func(capacity int) (*crossword.Crossword, crossword.Stats) {
+		builder := crossword.NewBuilderWithCorpus(5, 5, corpus, false)
+		builder.SetNogoodCapacity(capacity)
+
+		return builder.Build(), builder.Stats()
+	}
+
+	cw, stats := build(crossword.DEFAULT_NOGOOD_CAPACITY)
+	expected, expectedStats := build(0)
+
+	if cw == nil || expected == nil {
+		t.Fatalf("Expected both builds to succeed")
+	}
+	if cw.PrintData() != expected.PrintData() {
+		t.Errorf("Expected the no-good table to find\n%s\ngot\n%s", expected.PrintData(), cw.PrintData())
+	}
+	if stats.NogoodHits == 0 || stats.Calls >= expectedStats.Calls {
+		t.Errorf("Expected the no-good table to skip states, got %+v (without: %+v)", stats, expectedStats)
+	}
+
+	if _, stats := build(10); stats.Nogoods > 10 {
+		t.Errorf("Expected at most 10 no-goods to be kept, got %d", stats.Nogoods)
+	}
+}
+
+func TestRestarts(t *testing.T) {
+	schedule := crossword.LubyRestarts(10)
+	expected := []int{10, 10, 20, 10, 10, 20, 40, 10}
+	for attempt, cutoff := range expected {
+		if got := schedule(attempt); got != cutoff {
+			t.Errorf("Expected LubyRestarts(10)(%d) = %d, got %d", attempt, cutoff, got)
+		}
+	}
+
+	if got := crossword.GeometricRestarts(10, 2)(3); got != 80 {
+		t.Errorf("Expected GeometricRestarts(10, 2)(3) = 80, got %d", got)
+	}
+
+	words := readWords(t)
+	build := func() (*crossword.Crossword, crossword.Stats) {
+		builder := crossword.NewBuilder(4, 4, words, false)
+		builder.SetSeed(1)
+		builder.SetRestarts(crossword.LubyRestarts(4))
+
+		return builder.Build(), builder.Stats()
+	}
+
+	cw, stats := build()
+	if cw == nil {
+		t.Fatalf("Expected a restarted build to succeed")
+	}
+	if stats.Restarts == 0 {
+		t.Errorf("Expected a build with a cutoff of 4 calls to restart, got %+v", stats)
+	}
+
+	if again, _ := build(); again == nil || again.PrintData() != cw.PrintData() {
+		t.Errorf("Expected restarted builds with the same seed to match")
+	}
+}
+
+func TestCandidateCap(t *testing.T) {
+	// Trying only the first word of each cut leads to dead ends, but the grid can be filled
+	words := []crossword.Word{"aa", "ca", "bb", "bc", "cb", "ab"}
+
+	builder := crossword.NewBuilder(2, 2, words, false)
+	builder.SetCandidateCap(1)
+
+	_, err := builder.BuildContext(context.Background())
+
+	var noSolution *crossword.NoSolutionError
+	if !errors.As(err, &noSolution) || !noSolution.Capped {
+		t.Fatalf("Expected a capped NoSolutionError, got %v", err)
+	}
+	if !errors.Is(err, crossword.ErrNoSolution) {
+		t.Errorf("Expected the error to match ErrNoSolution")
+	}
+
+	// The first attempt fails as above, long before its cutoff, but each restart tries different words first
+	builder.SetRestarts(crossword.LubyRestarts(1000))
+	if _, err := builder.BuildContext(context.Background()); err != nil {
+		t.Errorf("Expected a capped build to restart until it succeeds, got %v", err)
+	}
+	builder.SetRestarts(nil)
+
+	builder.SetComplete(true)
+	if _, err := builder.BuildContext(context.Background()); err != nil {
+		t.Errorf("Expected a complete build to succeed, got %v", err)
+	}
+
+	builder = crossword.NewBuilder(2, 2, []crossword.Word{"ab", "cd"}, false)
+	builder.SetComplete(true)
+
+	_, err = builder.BuildContext(context.Background())
+	if !errors.As(err, &noSolution) || noSolution.Capped {
+		t.Errorf("Expected an uncapped NoSolutionError from a complete build, got %v", err)
+	}
+}
@@ -1,31 +1,298 @@
 package crossword
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/nitzanhen/crossword/src/structure"
+)
 
 type Word string
 
+// A letter at a given position within a word.
+type letterKey struct {
+	position int
+	letter   string
+}
+
+// Distinguishes regex lookups from pattern lookups in the corpus cache.
+// A pattern's empty symbol is part of its key, as builders sharing the corpus may use different symbols.
+type cacheKey struct {
+	regex   bool
+	pattern string
+	empty   string
+}
+
+// The default number of lookups a corpus keeps cached.
+const DEFAULT_CACHE_CAPACITY = 10_000
+
+// A list of words that can be searched by pattern.
+// Safe for concurrent use, so a single corpus may be shared between builders.
 type Corpus struct {
 	words []Word
-	cache map[string][]Word
+	// The form each word was written in before it was normalized, if it was (see NewNormalizedCorpus).
+	display map[Word]string
+
+	mu    sync.Mutex
+	cache *structure.LRU[cacheKey, []Word]
+
+	// The letters of each word, as split by Chars.
+	chars map[Word][]string
+	// The number of times each letter appears in the corpus, and the total number of letters.
+	letterCounts map[string]int
+	letters      int
+
+	// Indices into words, by word length and by the letter at each position.
+	byLength map[int]*structure.Bitset
+	byLetter map[letterKey]*structure.Bitset
+
+	hits, misses atomic.Int64
 }
 
-func NewCorpus(words []Word) Corpus {
-	return Corpus{words, make(map[string][]Word)}
+func NewCorpus(words []Word) *Corpus {
+	return NewCachedCorpus(words, DEFAULT_CACHE_CAPACITY)
 }
 
-func (c *Corpus) Filter(regex regexp.Regexp) []Word {
-	str := regex.String()
+// Creates a corpus that caches the results of at most cacheCapacity lookups, evicting the least recently used.
+// A capacity <= 0 disables caching.
+func NewCachedCorpus(words []Word, cacheCapacity int) *Corpus {
+	c := &Corpus{
+		words: words,
+		cache: structure.NewLRU[cacheKey, []Word](cacheCapacity),
+		chars: make(map[Word][]string, len(words)),
+
+		letterCounts: make(map[string]int),
+		byLength:     make(map[int]*structure.Bitset),
+		byLetter:     make(map[letterKey]*structure.Bitset),
+	}
+
+	for i, w := range words {
+		chars := Chars(string(w))
+		c.chars[w] = chars
+
+		for _, letter := range chars {
+			c.letterCounts[letter]++
+		}
+		c.letters += len(chars)
+
+		bitsetAt(c.byLength, len(chars), len(words)).Add(i)
+		for position, letter := range chars {
+			bitsetAt(c.byLetter, letterKey{position, letter}, len(words)).Add(i)
+		}
+	}
+
+	return c
+}
+
+// Creates a corpus of the normalized forms of the given words, remembering the form each was written in
+// so that it can be displayed (see Display). Words that normalize alike are kept once, in the form written first.
+func NewNormalizedCorpus(words []string, normalizer Normalizer) *Corpus {
+	normalized, display := NormalizeWords(words, normalizer)
+
+	c := NewCorpus(normalized)
+	c.display = display
+
+	return c
+}
+
+// Returns the bitset stored in index under key, creating an empty one if there is none.
+func bitsetAt[K comparable](index map[K]*structure.Bitset, key K, cap int) *structure.Bitset {
+	set, ok := index[key]
+	if !ok {
+		bitset := structure.NewBitset(cap)
+		set = &bitset
+		index[key] = set
+	}
+
+	return set
+}
+
+type CacheStats struct {
+	Hits     int `json:"hits"`
+	Misses   int `json:"misses"`
+	Size     int `json:"size"`     // Number of lookups currently cached.
+	Capacity int `json:"capacity"` // Maximum number of lookups cached.
+}
+
+func (c *Corpus) CacheStats() CacheStats {
+	c.mu.Lock()
+	size, capacity := c.cache.Size(), c.cache.Cap()
+	c.mu.Unlock()
+
+	return CacheStats{int(c.hits.Load()), int(c.misses.Load()), size, capacity}
+}
+
+// Checks and returns whether word is in the corpus.
+func (c *Corpus) Has(word Word) bool {
+	_, ok := c.chars[word]
+	return ok
+}
+
+// Checks and returns whether letter is one of the letters the corpus's words are split into (see Chars).
+func (c *Corpus) hasLetter(letter string) bool {
+	return c.letterCounts[letter] > 0
+}
+
+// Returns the form word was written in before it was normalized, or word itself if it was not.
+func (c *Corpus) Display(word Word) string {
+	if written, ok := c.display[word]; ok {
+		return written
+	}
+
+	return string(word)
+}
+
+// Returns the letters of word, as split by Chars.
+func (c *Corpus) charsOf(word Word) []string {
+	if chars, ok := c.chars[word]; ok {
+		return chars
+	}
 
-	if cached, ok := c.cache[str]; ok {
+	return Chars(string(word))
+}
+
+// Returns the share of the corpus' letters that are equal to letter.
+func (c *Corpus) LetterFrequency(letter string) float64 {
+	if c.letters == 0 {
+		return 0
+	}
+
+	return float64(c.letterCounts[letter]) / float64(c.letters)
+}
+
+// Returns the share of lookups that were answered from the cache, or 0 if there were none.
+func (c *Corpus) HitRate() float64 {
+	hits, misses := c.hits.Load(), c.misses.Load()
+	if hits+misses == 0 {
+		return 0
+	}
+
+	return float64(hits) / float64(hits+misses)
+}
+
+// Returns the cached result for key, computing and caching it first if there is none.
+// The index is read-only once built, so only the cache needs to be locked; compute runs unlocked.
+func (c *Corpus) lookup(key cacheKey, compute func() []Word) []Word {
+	c.mu.Lock()
+	cached, ok := c.cache.Get(key)
+	c.mu.Unlock()
+
+	if ok {
+		c.hits.Add(1)
 		return cached
 	}
+	c.misses.Add(1)
 
-	matches := Filter(
-		c.words,
-		func(w Word) bool { return regex.MatchString(string(w)) },
-	)
+	matches := compute()
 
-	c.cache[str] = matches
+	c.mu.Lock()
+	c.cache.Set(key, matches)
+	c.mu.Unlock()
 
 	return matches
 }
+
+// Returns the words matched by the given regex, by testing every word in the corpus.
+func (c *Corpus) Filter(regex regexp.Regexp) []Word {
+	return c.lookup(cacheKey{true, regex.String(), ""}, func() []Word {
+		return Filter(
+			c.words,
+			func(w Word) bool { return regex.MatchString(string(w)) },
+		)
+	})
+}
+
+// Returns the words that fit in a cut with the given data, in corpus order.
+// A word fits if it can be placed at some offset within the cut so that it covers all of the cut's letters
+// and agrees with each of them; cells equal to empty match any letter.
+// This is equivalent to filtering by the builder's graceful cut regex, but is answered from the corpus index.
+func (c *Corpus) Match(pattern []string, empty string) []Word {
+	return c.lookup(cacheKey{false, strings.Join(pattern, "\x00"), empty}, func() []Word {
+		return Map(
+			c.matchIndex(pattern, empty).ToSlice(),
+			func(i int) Word { return c.words[i] },
+		)
+	})
+}
+
+func (c *Corpus) matchIndex(pattern []string, empty string) *structure.Bitset {
+	n := len(pattern)
+	isLetter := func(value string) bool { return value != empty }
+
+	matches := structure.NewBitset(len(c.words))
+
+	first, last := FirstIndex(pattern, isLetter), LastIndex(pattern, isLetter)
+	if first == -1 {
+		// No letters - any word that is not too long fits
+		first, last = 0, -1
+	}
+
+	for length := Max(1, last-first+1); length <= n; length++ {
+		lengthSet, ok := c.byLength[length]
+		if !ok {
+			continue
+		}
+
+		// The word must start at or before the first letter, and end at or after the last one
+		for offset := Max(0, last-length+1); offset <= first && offset+length <= n; offset++ {
+			set := lengthSet.Copy()
+
+			for position := first; position <= last; position++ {
+				if !isLetter(pattern[position]) {
+					continue
+				}
+
+				letterSet, ok := c.byLetter[letterKey{position - offset, pattern[position]}]
+				if !ok {
+					set = nil
+					break
+				}
+				set.Intersect(letterSet)
+			}
+
+			if set != nil {
+				matches.Union(set)
+			}
+		}
+	}
+
+	return &matches
+}
+
+// Checks and returns whether word matches data letter for letter, where cells equal to empty match any letter.
+func Fits(data []string, word Word, empty string) bool {
+	return fitsChars(data, Chars(string(word)), empty)
+}
+
+func fitsChars(data []string, chars []string, empty string) bool {
+	if len(chars) != len(data) {
+		return false
+	}
+
+	for i, value := range data {
+		if value != empty && value != chars[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Checks and returns whether word fits in a cut with the given data, in the sense of Corpus.Match.
+func FitsIn(data []string, word Word, empty string) bool {
+	isLetter := func(value string) bool { return value != empty }
+	first, last := FirstIndex(data, isLetter), LastIndex(data, isLetter)
+
+	n := len([]rune(word))
+	for offset := 0; offset+n <= len(data); offset++ {
+		coversLetters := first == -1 || (offset <= first && last < offset+n)
+
+		if coversLetters && Fits(data[offset:offset+n], word, empty) {
+			return true
+		}
+	}
+
+	return false
+}
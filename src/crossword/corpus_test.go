@@ -0,0 +1,98 @@
+package crossword_test
+
+import (
+	"reflect"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+)
+
+func TestCorpusMatch(t *testing.T) {
+	corpus := crossword.NewCorpus([]crossword.Word{"a", "ab", "ba", "abc", "cab", "bca", "abcd"})
+
+	cases := []struct {
+		pattern []string
+		regex   string
+	}{
+		{[]string{".", ".", "."}, "^.{1,3}$"},
+		{[]string{"a", ".", "."}, "^a.{0,2}$"},
+		{[]string{".", "a", "."}, "^.{0,1}a.{0,1}$"},
+		{[]string{".", "b", ".", "."}, "^.{0,1}b.{0,2}$"},
+		{[]string{"c", ".", "b"}, "^c.b$"},
+		{[]string{"d", "."}, "^d.{0,1}$"},
+	}
+
+	for _, c := range cases {
+		regex := regexp.MustCompile(c.regex)
+
+		got := corpus.Match(c.pattern, ".")
+		want := corpus.Filter(*regex)
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected corpus.Match(%v) = %v, got %v", c.pattern, want, got)
+		}
+	}
+
+	if hitRate := corpus.HitRate(); hitRate != 0 {
+		t.Errorf("Expected corpus.HitRate() = 0 before any repeated lookup, got %f", hitRate)
+	}
+	corpus.Match([]string{"c", ".", "b"}, ".")
+	if hitRate := corpus.HitRate(); hitRate == 0 {
+		t.Errorf("Expected corpus.HitRate() > 0 after a repeated lookup")
+	}
+
+	// Builders with another empty symbol share the corpus, but not its cached results
+	if got := corpus.Match([]string{"c", "_", "b"}, "_"); !reflect.DeepEqual(got, []crossword.Word{"cab"}) {
+		t.Errorf("Expected corpus.Match() with another empty symbol = [cab], got %v", got)
+	}
+	if got := corpus.Match([]string{"c", "_", "b"}, "."); len(got) != 0 {
+		t.Errorf("Expected _ to be a letter when it isn't the empty symbol, got %v", got)
+	}
+}
+
+func TestFits(t *testing.T) {
+	if !crossword.Fits([]string{"a", ".", "c"}, "abc", ".") {
+		t.Errorf("Expected abc to fit a.c")
+	}
+	if crossword.Fits([]string{"a", ".", "c"}, "abd", ".") {
+		t.Errorf("Expected abd not to fit a.c")
+	}
+	if crossword.Fits([]string{"a", "."}, "abc", ".") {
+		t.Errorf("Expected abc not to fit a. (length mismatch)")
+	}
+
+	if !crossword.FitsIn([]string{".", "b", ".", "."}, "ab", ".") {
+		t.Errorf("Expected ab to fit in .b..")
+	}
+	if crossword.FitsIn([]string{".", "b", ".", "d"}, "ab", ".") {
+		t.Errorf("Expected ab not to fit in .b.d, as it does not cover d")
+	}
+}
+
+func TestCorpusCache(t *testing.T) {
+	corpus := crossword.NewCachedCorpus(readWords(t), 50)
+
+	// Builders sharing the corpus may search it concurrently
+	var wg sync.WaitGroup
+	for seed := int64(0); seed < 4; seed++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+
+			builder := crossword.NewBuilderWithCorpus(3, 3, corpus, false)
+			builder.SetSeed(seed)
+			builder.Build()
+		}(seed)
+	}
+	wg.Wait()
+
+	stats := corpus.CacheStats()
+	if stats.Capacity != 50 || stats.Size > 50 {
+		t.Errorf("Expected at most 50 cached lookups, got %+v", stats)
+	}
+	if stats.Hits == 0 || stats.Misses == 0 {
+		t.Errorf("Expected both hits and misses to be counted, got %+v", stats)
+	}
+}
@@ -102,6 +102,21 @@ func (cw *Crossword) Copy() Crossword {
 	return copy
 }
 
+// Returns a copy of the crossword as it is published, with its empty cells as stops.
+// Once a crossword is complete, no word will be written to them (see SetMaxStopShare).
+func (cw *Crossword) Completed() Crossword {
+	completed := cw.Copy()
+	for _, row := range completed.Data {
+		for j, value := range row {
+			if value == completed.Empty {
+				row[j] = completed.Stop
+			}
+		}
+	}
+
+	return completed
+}
+
 type CutWithWord struct {
 	Cut  Cut  `json:"cut"`
 	Word Word `json:"word"`
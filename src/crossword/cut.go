@@ -26,6 +26,21 @@ func (cut *Cut) Copy() Cut {
 	return Cut{cut.Row, cut.Col, cut.Orientation, cut.Len}
 }
 
+// Orders cuts by orientation, then row, column and length.
+// Returns a negative number if cut comes first, a positive one if other does, and 0 if they are equal.
+func compareCuts(cut, other Cut) int {
+	switch {
+	case cut.Orientation != other.Orientation:
+		return int(cut.Orientation) - int(other.Orientation)
+	case cut.Row != other.Row:
+		return cut.Row - other.Row
+	case cut.Col != other.Col:
+		return cut.Col - other.Col
+	}
+
+	return cut.Len - other.Len
+}
+
 type CutStatus int
 
 const (
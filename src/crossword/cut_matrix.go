@@ -15,6 +15,10 @@ type CutMatrix struct {
 	Stop  string `json:"stop"`  // Indicates a cell that cannot have a value, i.e. cannot be in any cut.
 
 	Data [][]string `json:"data"`
+
+	// The direction horizontal cuts are read in. Right to left, the first character of a horizontal cut
+	// is written to its last column, so that data always holds the grid as it is displayed.
+	Direction Direction `json:"direction"`
 }
 
 func NewCutMatrix(width, height int, empty, stop string) CutMatrix {
@@ -24,9 +28,10 @@ func NewCutMatrix(width, height int, empty, stop string) CutMatrix {
 		func(i, j int) string { return empty },
 	)
 
-	return CutMatrix{width, height, empty, stop, data}
+	return CutMatrix{width, height, empty, stop, data, LEFT_TO_RIGHT}
 }
 
+// Moves step cells along the orientation, towards increasing rows or columns regardless of direction.
 func Move(row, col int, o Orientation, step int) (i, j int) {
 	switch o {
 	case HORIZONTAL:
@@ -49,17 +54,38 @@ func IsInCut(i, j int, cut Cut) bool {
 	return false
 }
 
+// Moves step cells along the orientation in the matrix' reading direction,
+// i.e. towards decreasing columns when moving horizontally right to left. Move, by contrast, moves geometrically.
+func (mat *CutMatrix) ReadingMove(row, col int, o Orientation, step int) (i, j int) {
+	if o == HORIZONTAL && mat.Direction == RIGHT_TO_LEFT {
+		return Move(row, col, o, -step)
+	}
+
+	return Move(row, col, o, step)
+}
+
+// Returns the cell the cut is read from: its first, or the last for a horizontal cut read right to left.
+func (mat *CutMatrix) startOf(cut Cut) (row, col int) {
+	if cut.Orientation == HORIZONTAL && mat.Direction == RIGHT_TO_LEFT {
+		return cut.Row, cut.Col + cut.Len - 1
+	}
+
+	return cut.Row, cut.Col
+}
+
 type CellData struct {
 	i, j  int
 	value string
 }
 
+// Returns the cells of the cut in reading order.
 func (mat *CutMatrix) IterateCut(cut Cut) []CellData {
-	row, col, o, len := cut.Row, cut.Col, cut.Orientation, cut.Len
+	row, col := mat.startOf(cut)
+	o, len := cut.Orientation, cut.Len
 
 	data := make([]CellData, len)
 	for k := 0; k < len; k++ {
-		i, j := Move(row, col, o, k)
+		i, j := mat.ReadingMove(row, col, o, k)
 		data[k] = CellData{i, j, mat.Data[i][j]}
 	}
 
@@ -79,10 +105,11 @@ func (mat *CutMatrix) getSubcuts(cut Cut) []Cut {
 
 	subcuts := structure.List[Cut]{}
 
-	for _, data := range mat.IterateCut(cut) {
-		i, j, value := data.i, data.j, data.value
+	// Cuts span increasing rows and columns, whichever direction they are read in
+	for k := 0; k < cut.Len; k++ {
+		i, j := Move(cut.Row, cut.Col, o, k)
 
-		if value == mat.Stop {
+		if mat.Data[i][j] == mat.Stop {
 			if len > 0 {
 				subcuts.Add(Cut{startRow, startCol, o, len})
 			}
@@ -101,10 +128,15 @@ func (mat *CutMatrix) getSubcuts(cut Cut) []Cut {
 }
 
 func (mat *CutMatrix) SubcutsOf(cuts []Cut) []Cut {
+	return mat.SubcutsOfMinLength(cuts, 2)
+}
+
+// Returns the runs of the given cuts between stops, leaving out those shorter than minLength.
+func (mat *CutMatrix) SubcutsOfMinLength(cuts []Cut, minLength int) []Cut {
 	subcuts := structure.List[Cut]{}
 	for _, cut := range cuts {
 		for _, subcut := range mat.getSubcuts(cut) {
-			if subcut.Len > 1 {
+			if subcut.Len >= minLength {
 				subcuts.Add(subcut)
 			}
 		}
@@ -153,7 +185,12 @@ func (mat *CutMatrix) Set(i, j int, value string) error {
 	return nil
 }
 
+// Returns the part of the cut from its start-th character up to its end-th, in reading order.
 func (mat *CutMatrix) Subcut(cut Cut, start, end int) Cut {
+	if cut.Orientation == HORIZONTAL && mat.Direction == RIGHT_TO_LEFT {
+		return Cut{cut.Row, cut.Col + cut.Len - end, cut.Orientation, end - start}
+	}
+
 	row, col := Move(cut.Row, cut.Col, cut.Orientation, start)
 
 	return Cut{row, col, cut.Orientation, end - start}
@@ -198,9 +235,29 @@ func (mat *CutMatrix) DoCutsMeet(cut1, cut2 Cut) bool {
 	return IsInCut(intI, intJ, cut1) && IsInCut(intI, intJ, cut2)
 }
 
+// Returns the number of cells that are not empty, i.e. hold either a letter or a stop.
+func (mat *CutMatrix) CountFilled() int {
+	filled := 0
+	for _, row := range mat.Data {
+		for _, value := range row {
+			if value != mat.Empty {
+				filled++
+			}
+		}
+	}
+
+	return filled
+}
+
+// Returns the data as text, row by row, with the columns in the order they are displayed.
+// Right-to-left rows are wrapped in a left-to-right override, so that terminals do not reorder their letters.
 func (mat *CutMatrix) PrintData() string {
 	rowStrings := Map(mat.Data, func(row []string) string {
-		return "| " + strings.Join(row, " | ") + " |"
+		line := "| " + strings.Join(row, " | ") + " |"
+		if mat.Direction == RIGHT_TO_LEFT {
+			line = "\u202d" + line + "\u202c"
+		}
+		return line
 	})
 
 	return strings.Join(rowStrings, "\n")
@@ -212,6 +269,7 @@ func (mat *CutMatrix) Copy() CutMatrix {
 	copy.Height = mat.Height
 	copy.Empty = mat.Empty
 	copy.Stop = mat.Stop
+	copy.Direction = mat.Direction
 
 	copy.Data = MakeMatrix(
 		mat.Height, mat.Width,
@@ -0,0 +1,74 @@
+package crossword_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+)
+
+func TestRightToLeft(t *testing.T) {
+	cw := crossword.NewCrossword(4, 2)
+	cw.Direction = crossword.RIGHT_TO_LEFT
+
+	cut := crossword.Cut{Row: 0, Col: 0, Orientation: crossword.HORIZONTAL, Len: 4}
+	if err := cw.Embed(cw.Subcut(cut, 0, 3), "שלם"); err != nil {
+		t.Fatalf("Expected cw.Embed() to succeed, got %v", err)
+	}
+
+	// The first letter is written to the rightmost cell, and a stop before it
+	if expected := []string{cw.Stop, "ם", "ל", "ש"}; !reflect.DeepEqual(cw.Data[0], expected) {
+		t.Errorf("Expected row %v, got %v", expected, cw.Data[0])
+	}
+	if row, col := cw.ReadingMove(0, 3, crossword.HORIZONTAL, 1); row != 0 || col != 2 {
+		t.Errorf("Expected to move leftwards, got (%d, %d)", row, col)
+	}
+	if data := strings.Join(cw.GetCutData(cw.Embeddings[0].Cut), ""); data != "שלם" {
+		t.Errorf("Expected the cut to read %q, got %q", "שלם", data)
+	}
+	if !strings.HasPrefix(cw.PrintData(), "\u202d| 1 | ם | ל | ש |\u202c") {
+		t.Errorf("Expected the rows as displayed, got %q", cw.PrintData())
+	}
+
+	// Vertical cuts are read top to bottom either way
+	if err := cw.Embed(crossword.Cut{Row: 0, Col: 3, Orientation: crossword.VERTICAL, Len: 2}, "שם"); err != nil || cw.Data[1][3] != "ם" {
+		t.Errorf("Expected a vertical word to be written downwards, got %v:\n%s", err, cw.PrintData())
+	}
+
+	words := []crossword.Word{"abc", "ade", "bfg", "cfh", "dgi", "ehi", "ab", "cd", "ba", "dc", "bd", "ac"}
+	for _, size := range []int{2, 3} {
+		counts := map[crossword.Direction]int{}
+		for _, direction := range []crossword.Direction{crossword.LEFT_TO_RIGHT, crossword.RIGHT_TO_LEFT} {
+			builder := crossword.NewBuilder(size, size, words, false)
+			builder.SetDirection(direction)
+
+			count, err := builder.Count(context.Background())
+			if err != nil {
+				t.Fatalf("Expected builder.Count() to succeed, got %v", err)
+			}
+			counts[direction] = count
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			built, err := builder.BuildContext(ctx)
+			cancel()
+			if err != nil {
+				continue
+			}
+
+			if built.Direction != direction {
+				t.Errorf("%dx%d: expected a crossword read %v, got %v", size, size, direction, built.Direction)
+			}
+			if violations := built.Validate(crossword.NewCorpus(words)); len(violations) > 0 {
+				t.Errorf("%dx%d %v: expected a valid crossword, got %v:\n%s", size, size, direction, violations, built.PrintData())
+			}
+		}
+
+		// Each crossword read one way mirrors one read the other way
+		if counts[crossword.LEFT_TO_RIGHT] == 0 || counts[crossword.LEFT_TO_RIGHT] != counts[crossword.RIGHT_TO_LEFT] {
+			t.Errorf("%dx%d: expected as many crosswords either way, got %v", size, size, counts)
+		}
+	}
+}
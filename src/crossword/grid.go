@@ -0,0 +1,85 @@
+package crossword
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nitzanhen/crossword/src/structure"
+)
+
+// Checks that every embedding of the crossword lies within its bounds, is written in its data
+// and is delimited by stops (or the grid's edges), and that no word is embedded twice.
+func checkEmbeddings(cw *Crossword) error {
+	if violations := cw.embeddingViolations(); len(violations) > 0 {
+		return fmt.Errorf("invalid grid: %w", violations[0])
+	}
+
+	return nil
+}
+
+// Makes the builder complete the given crossword rather than start from an empty grid.
+// The crossword may hold letters, stops and embeddings; those are kept, and the rest of the grid is filled around them.
+// The grid is copied, so later changes to it do not affect the builder. A nil grid restores the empty one.
+//
+// Returns an error, leaving the builder unchanged, if the grid's dimensions differ from the builder's
+// or its embeddings do not agree with its data.
+func (builder *Builder) SetGrid(grid *Crossword) error {
+	if grid == nil {
+		builder.grid = nil
+		return nil
+	}
+
+	if grid.Width != builder.width || grid.Height != builder.height {
+		return fmt.Errorf(
+			"invalid grid: dimensions are %dx%d, builder's are %dx%d",
+			grid.Width, grid.Height, builder.width, builder.height,
+		)
+	}
+
+	if err := checkEmbeddings(grid); err != nil {
+		return err
+	}
+
+	copy := grid.Copy()
+	builder.grid = &copy
+
+	return nil
+}
+
+// Sets the direction the words of crosswords built from an empty grid are written in (see CutMatrix.Direction).
+// A grid set with SetGrid keeps its own direction.
+func (builder *Builder) SetDirection(direction Direction) {
+	builder.direction = direction
+}
+
+// Returns the crossword a build starts from, with its template's stops and the pinned theme words embedded,
+// along with the cuts left to fill in it. Returns an error if the grid breaks the template or symmetry,
+// or if the theme words cannot all be placed (see placeThemeWords).
+func (builder *Builder) start(ctx context.Context) (Crossword, structure.Set[Cut], error) {
+	var cw Crossword
+	if builder.grid == nil {
+		cw = NewCrossword(builder.width, builder.height)
+		cw.Direction = builder.direction
+	} else {
+		cw = builder.grid.Copy()
+	}
+
+	if err := builder.applyLayout(&cw); err != nil {
+		return cw, structure.Set[Cut]{}, err
+	}
+	if err := builder.placeThemeWords(ctx, &cw); err != nil {
+		return cw, structure.Set[Cut]{}, err
+	}
+
+	return cw, builder.openCuts(&cw), nil
+}
+
+// Returns the cuts of cw that are left to fill: those long enough to hold a word, and not yet embedded.
+func (builder *Builder) openCuts(cw *Crossword) structure.Set[Cut] {
+	cuts := Filter(
+		builder.subcutsOf(cw, cw.GetCuts()),
+		func(cut Cut) bool { return !cw.IsCutEmbedded(cut) },
+	)
+
+	return structure.SetFromSlice(cuts)
+}
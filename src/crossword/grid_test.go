@@ -0,0 +1,58 @@
+package crossword_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+)
+
+func TestSetGrid(t *testing.T) {
+	grid := crossword.NewCrossword(2, 2)
+	grid.Embed(crossword.Cut{Row: 0, Col: 0, Orientation: crossword.HORIZONTAL, Len: 2}, "ac")
+
+	builder := crossword.NewBuilder(2, 2, squareWords, false)
+	if err := builder.SetGrid(&grid); err != nil {
+		t.Fatalf("Expected builder.SetGrid() to succeed, got %v", err)
+	}
+
+	// The grid is copied
+	grid.Data[1][1] = "x"
+
+	cw, err := builder.BuildContext(context.Background())
+	if err != nil {
+		t.Fatalf("Expected builder.BuildContext() to succeed, got %v", err)
+	}
+	if cw.Data[0][0] != "a" || cw.Data[0][1] != "c" || len(cw.Embeddings) != 4 {
+		t.Errorf("Expected the crossword to complete the grid, got:\n%s", cw.PrintData())
+	}
+
+	// Only ac/bd keeps the embedding
+	if count, _ := builder.Count(context.Background()); count != 1 {
+		t.Errorf("Expected 1 crossword completing the grid, got %d", count)
+	}
+
+	// Letters may be seeded without an embedding
+	letters := crossword.NewCrossword(2, 2)
+	letters.Set(0, 1, "b")
+	builder.SetGrid(&letters)
+	if count, _ := builder.Count(context.Background()); count != 1 {
+		t.Errorf("Expected 1 crossword completing the seeded letter, got %d", count)
+	}
+
+	builder.SetGrid(nil)
+	if count, _ := builder.Count(context.Background()); count != 2 {
+		t.Errorf("Expected 2 crosswords once the grid is reset, got %d", count)
+	}
+
+	small := crossword.NewCrossword(1, 2)
+	if err := builder.SetGrid(&small); err == nil {
+		t.Errorf("Expected an error for a grid of different dimensions")
+	}
+
+	mismatched := grid.Copy()
+	mismatched.Data[0][1] = "d"
+	if err := builder.SetGrid(&mismatched); err == nil {
+		t.Errorf("Expected an error for an embedding that disagrees with the data")
+	}
+}
@@ -0,0 +1,126 @@
+package crossword
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// The version of the JSON schema crosswords are written in.
+// Crosswords written before the schema was versioned have no version, and are read as version 1.
+// Version 2 adds the direction; earlier versions are read left to right.
+const SCHEMA_VERSION = 2
+
+// Checks that the matrix' data has its dimensions, that its empty and stop values differ,
+// and that its direction is valid.
+func (mat *CutMatrix) check() error {
+	if mat.Width < 0 || mat.Height < 0 {
+		return fmt.Errorf("invalid dimensions %dx%d", mat.Width, mat.Height)
+	}
+	if mat.Empty == mat.Stop {
+		return fmt.Errorf("empty and stop are both %q", mat.Empty)
+	}
+	if mat.Direction != LEFT_TO_RIGHT && mat.Direction != RIGHT_TO_LEFT {
+		return fmt.Errorf("invalid direction %d", int(mat.Direction))
+	}
+	if len(mat.Data) != mat.Height {
+		return fmt.Errorf("data has %d rows, expected %d", len(mat.Data), mat.Height)
+	}
+
+	for i, row := range mat.Data {
+		if len(row) != mat.Width {
+			return fmt.Errorf("row %d of data has %d cells, expected %d", i, len(row), mat.Width)
+		}
+	}
+
+	return nil
+}
+
+func (mat *CutMatrix) UnmarshalJSON(raw []byte) error {
+	// Decode into a type without this method, so as not to recurse
+	type cutMatrix CutMatrix
+
+	var decoded cutMatrix
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return err
+	}
+
+	matrix := CutMatrix(decoded)
+	if err := matrix.check(); err != nil {
+		return fmt.Errorf("invalid cut matrix: %w", err)
+	}
+
+	*mat = matrix
+
+	return nil
+}
+
+// The JSON form of a crossword.
+type crosswordJSON struct {
+	Version int `json:"version"`
+
+	Width  int        `json:"width"`
+	Height int        `json:"height"`
+	Empty  string     `json:"empty"`
+	Stop   string     `json:"stop"`
+	Data   [][]string `json:"data"`
+
+	Direction Direction `json:"direction"`
+
+	Embeddings []CutWithWord `json:"embeddings"`
+}
+
+func (cw Crossword) MarshalJSON() ([]byte, error) {
+	return json.Marshal(crosswordJSON{
+		SCHEMA_VERSION,
+		cw.Width, cw.Height, cw.Empty, cw.Stop, cw.Data,
+		cw.Direction,
+		cw.Embeddings,
+	})
+}
+
+// Reads a crossword written by MarshalJSON, in the current version of the schema or an earlier one.
+// Returns an error if the version is unknown, or if the crossword's matrix is malformed.
+// Embeddings are not checked against the data; LoadCrossword does that.
+func (cw *Crossword) UnmarshalJSON(raw []byte) error {
+	var decoded crosswordJSON
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return err
+	}
+
+	switch decoded.Version {
+	case 0, 1:
+		// Version 0 is the unversioned schema, which version 1 only adds the version to
+	case 2:
+	default:
+		return fmt.Errorf("invalid crossword: unsupported schema version %d", decoded.Version)
+	}
+
+	matrix := CutMatrix{decoded.Width, decoded.Height, decoded.Empty, decoded.Stop, decoded.Data, decoded.Direction}
+	if err := matrix.check(); err != nil {
+		return fmt.Errorf("invalid crossword: %w", err)
+	}
+
+	cw.CutMatrix = matrix
+	cw.Embeddings = decoded.Embeddings
+	if cw.Embeddings == nil {
+		cw.Embeddings = []CutWithWord{}
+	}
+
+	return nil
+}
+
+// Reads a crossword written as JSON, and checks that it is consistent: its data has its dimensions,
+// and its embeddings lie within it, match its cells and are delimited by stops.
+func LoadCrossword(reader io.Reader) (*Crossword, error) {
+	var cw Crossword
+	if err := json.NewDecoder(reader).Decode(&cw); err != nil {
+		return nil, err
+	}
+
+	if violations := cw.embeddingViolations(); len(violations) > 0 {
+		return nil, fmt.Errorf("invalid crossword: %w", violations[0])
+	}
+
+	return &cw, nil
+}
@@ -0,0 +1,60 @@
+package crossword_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+)
+
+func TestLoadCrossword(t *testing.T) {
+	builder := crossword.NewBuilder(2, 2, squareWords, false)
+	cw := builder.Build()
+
+	raw, err := json.Marshal(cw)
+	if err != nil {
+		t.Fatalf("Expected json.Marshal() to succeed, got %v", err)
+	}
+	if !strings.Contains(string(raw), `"version":2`) {
+		t.Errorf("Expected the schema version to be written, got %s", raw)
+	}
+
+	loaded, err := crossword.LoadCrossword(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Expected LoadCrossword() to succeed, got %v", err)
+	}
+	if !reflect.DeepEqual(loaded, cw) {
+		t.Errorf("Expected the crossword to round trip, got %+v, expected %+v", loaded, cw)
+	}
+
+	// As written before the schema was versioned
+	legacy := `{"width":2,"height":1,"empty":".","stop":"1","data":[["a","b"]],
+		"embeddings":[{"cut":{"row":0,"col":0,"orientation":0,"len":2},"word":"ab"}]}`
+	if loaded, err := crossword.LoadCrossword(strings.NewReader(legacy)); err != nil || !loaded.IsWordEmbedded("ab") {
+		t.Errorf("Expected an unversioned crossword to load, got %v", err)
+	}
+
+	invalid := map[string]string{
+		"unknown version": `{"version":99,"width":1,"height":1,"empty":".","stop":"1","data":[["."]]}`,
+		"missing row":     `{"width":1,"height":2,"empty":".","stop":"1","data":[["."]]}`,
+		"short row":       `{"width":2,"height":1,"empty":".","stop":"1","data":[["."]]}`,
+		"same symbols":    `{"width":1,"height":1,"empty":".","stop":".","data":[["."]]}`,
+		"embedding mismatch": `{"width":2,"height":1,"empty":".","stop":"1","data":[["a","b"]],
+			"embeddings":[{"cut":{"row":0,"col":0,"orientation":0,"len":2},"word":"ba"}]}`,
+		"embedding out of bounds": `{"width":2,"height":1,"empty":".","stop":"1","data":[["a","b"]],
+			"embeddings":[{"cut":{"row":0,"col":1,"orientation":0,"len":2},"word":"ab"}]}`,
+	}
+	for name, raw := range invalid {
+		if _, err := crossword.LoadCrossword(strings.NewReader(raw)); err == nil {
+			t.Errorf("%s: expected LoadCrossword() to fail", name)
+		}
+	}
+
+	var mat crossword.CutMatrix
+	if err := json.Unmarshal([]byte(invalid["short row"]), &mat); err == nil {
+		t.Errorf("Expected unmarshaling a malformed cut matrix to fail")
+	}
+}
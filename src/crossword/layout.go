@@ -0,0 +1,179 @@
+package crossword
+
+import "fmt"
+
+// A symmetry the stops of a crossword must keep.
+type Symmetry int
+
+const (
+	NO_SYMMETRY Symmetry = iota
+	ROTATIONAL  Symmetry = iota // Unchanged by a half turn, as in American-style grids
+	MIRROR      Symmetry = iota // Unchanged by reflection across the vertical axis
+)
+
+func (symmetry Symmetry) String() string {
+	switch symmetry {
+	case NO_SYMMETRY:
+		return "none"
+	case ROTATIONAL:
+		return "rotational"
+	case MIRROR:
+		return "mirror"
+	}
+
+	panic(fmt.Sprintf("Invalid Symmetry %d", int(symmetry)))
+}
+
+// Returns the cell symmetric to (row, col) in a grid of the given dimensions.
+func (symmetry Symmetry) mirror(row, col, width, height int) (i, j int) {
+	switch symmetry {
+	case ROTATIONAL:
+		return height - 1 - row, width - 1 - col
+	case MIRROR:
+		return row, width - 1 - col
+	}
+
+	return row, col
+}
+
+// Makes the builder fill the cuts of a fixed pattern of stops, rather than placing stops as it sees fit.
+// stops[row][col] tells whether the cell is a stop; every other cell must be filled by the words of its cuts,
+// so each word fills an entire cut. A nil template lets the builder place stops again.
+//
+// Returns an error, leaving the builder unchanged, if the template's dimensions differ from the builder's
+// or it leaves cells that are in no cut.
+func (builder *Builder) SetTemplate(stops [][]bool) error {
+	if stops == nil {
+		builder.template = nil
+		return nil
+	}
+
+	isMisshapen := func(row []bool) bool { return len(row) != builder.width }
+	if len(stops) != builder.height || FirstIndex(stops, isMisshapen) != -1 {
+		return fmt.Errorf("invalid template: dimensions must be %dx%d", builder.width, builder.height)
+	}
+
+	isOpen := func(i, j int) bool {
+		return 0 <= i && i < builder.height && 0 <= j && j < builder.width && !stops[i][j]
+	}
+
+	for i, row := range stops {
+		for j, stop := range row {
+			if !stop && !isOpen(i-1, j) && !isOpen(i+1, j) && !isOpen(i, j-1) && !isOpen(i, j+1) {
+				return fmt.Errorf("invalid template: cell (%d, %d) is in no cut", i, j)
+			}
+		}
+	}
+
+	builder.template = MakeMatrix(builder.height, builder.width, func(i, j int) bool { return stops[i][j] })
+
+	return nil
+}
+
+// Makes the builder keep the stops of every crossword symmetric: whenever it places a stop,
+// it also places one in the symmetric cell, and it never places a letter symmetric to a stop.
+// Stops placed this way cannot be traced back to a single embedding, so backjumping is not done under a symmetry.
+func (builder *Builder) SetSymmetry(symmetry Symmetry) {
+	builder.symmetry = symmetry
+}
+
+// Checks whether embedding a word in subcut keeps to the builder's template and symmetry.
+// Embedding writes stops to the empty cells before and after the subcut: a template allows no new stops,
+// and a symmetry requires their symmetric cells to be free of letters, and the letters' to be free of stops.
+func (builder *Builder) allowsPlacement(cw *Crossword, subcut Cut) bool {
+	if builder.template == nil && builder.symmetry == NO_SYMMETRY {
+		return true
+	}
+
+	isLetter := func(value string) bool { return value != cw.Empty && value != cw.Stop }
+
+	for _, step := range []int{-1, subcut.Len} {
+		i, j := Move(subcut.Row, subcut.Col, subcut.Orientation, step)
+		if !cw.IsValid(i, j) || cw.Data[i][j] != cw.Empty {
+			continue
+		}
+
+		if builder.template != nil {
+			return false
+		}
+
+		mirrorI, mirrorJ := builder.symmetry.mirror(i, j, cw.Width, cw.Height)
+		if isLetter(cw.Data[mirrorI][mirrorJ]) || IsInCut(mirrorI, mirrorJ, subcut) {
+			return false
+		}
+	}
+
+	if builder.symmetry != NO_SYMMETRY {
+		for _, cell := range cw.IterateCut(subcut) {
+			mirrorI, mirrorJ := builder.symmetry.mirror(cell.i, cell.j, cw.Width, cw.Height)
+			if cell.value == cw.Empty && cw.Data[mirrorI][mirrorJ] == cw.Stop {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Places a stop symmetric to each of cw's stops, as required by the builder's symmetry.
+// Returns an error if one of those cells holds a letter.
+func (builder *Builder) mirrorStops(cw *Crossword) error {
+	if builder.symmetry == NO_SYMMETRY {
+		return nil
+	}
+
+	for i, row := range cw.Data {
+		for j, value := range row {
+			if value != cw.Stop {
+				continue
+			}
+
+			mirrorI, mirrorJ := builder.symmetry.mirror(i, j, cw.Width, cw.Height)
+			if err := cw.Set(mirrorI, mirrorJ, cw.Stop); err != nil {
+				return fmt.Errorf("invalid grid: the stop at (%d, %d) breaks %v symmetry", i, j, builder.symmetry)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Writes the stops of the builder's template to cw, and places the stops its symmetry requires.
+// Returns an error if one of those falls on a letter, or if cw has stops outside the template.
+func (builder *Builder) applyLayout(cw *Crossword) error {
+	for i, row := range builder.template {
+		for j, stop := range row {
+			if stop && cw.Set(i, j, cw.Stop) != nil {
+				return fmt.Errorf("invalid grid: the template's stop at (%d, %d) falls on a letter", i, j)
+			}
+		}
+	}
+
+	if err := builder.mirrorStops(cw); err != nil {
+		return err
+	}
+
+	for i, row := range builder.template {
+		for j, stop := range row {
+			if !stop && cw.Data[i][j] == cw.Stop {
+				return fmt.Errorf("invalid grid: cell (%d, %d) is a stop outside the template", i, j)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Returns the cells the builder's layout makes stops before a build: those of its grid and template,
+// and their mirrors under its symmetry. A grid that breaks the layout keeps the stops placed before the break.
+func (builder *Builder) layoutStops() [][]bool {
+	var cw Crossword
+	if builder.grid == nil {
+		cw = NewCrossword(builder.width, builder.height)
+	} else {
+		cw = builder.grid.Copy()
+	}
+	builder.applyLayout(&cw)
+
+	return MakeMatrix(builder.height, builder.width, func(i, j int) bool { return cw.Data[i][j] == cw.Stop })
+}
@@ -0,0 +1,91 @@
+package crossword_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+)
+
+func TestTemplate(t *testing.T) {
+	template := [][]bool{
+		{false, false, false},
+		{false, true, false},
+		{false, false, false},
+	}
+	words := []crossword.Word{"abc", "ghi", "adg", "cfi", "ab", "hi", "gh"}
+
+	builder := crossword.NewBuilder(3, 3, words, false)
+	if err := builder.SetTemplate(template); err != nil {
+		t.Fatalf("Expected builder.SetTemplate() to succeed, got %v", err)
+	}
+
+	solutions := 0
+	all, _ := builder.Solutions(context.Background(), 0)
+	for cw := range all {
+		solutions++
+
+		for i, row := range cw.Data {
+			for j, value := range row {
+				if (value == cw.Stop) != template[i][j] {
+					t.Errorf("Expected the stops to follow the template, got:\n%s", cw.PrintData())
+				}
+			}
+		}
+		for _, cutword := range cw.Embeddings {
+			if cutword.Cut.Len != 3 {
+				t.Errorf("Expected every word to fill its cut, got %v in %v", cutword.Word, cutword.Cut.String())
+			}
+		}
+	}
+
+	// The square and its transpose
+	if solutions != 2 {
+		t.Errorf("Expected 2 crosswords following the template, got %d", solutions)
+	}
+
+	if err := builder.SetTemplate([][]bool{{false, true}, {true, false}}); err == nil {
+		t.Errorf("Expected an error for a template of different dimensions")
+	}
+
+	isolated := [][]bool{
+		{false, true, false},
+		{true, true, false},
+		{false, false, false},
+	}
+	if err := builder.SetTemplate(isolated); err == nil {
+		t.Errorf("Expected an error for a template with a cell in no cut")
+	}
+}
+
+func TestSymmetry(t *testing.T) {
+	words := readWords(t)
+
+	for _, symmetry := range []crossword.Symmetry{crossword.ROTATIONAL, crossword.MIRROR} {
+		builder := crossword.NewBuilder(5, 5, shuffleWords(words, 1), false)
+		builder.SetSeed(1)
+		builder.SetRestarts(crossword.LubyRestarts(100))
+		builder.SetSymmetry(symmetry)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		cw, err := builder.BuildContext(ctx)
+		cancel()
+		if err != nil {
+			t.Fatalf("Expected a %v build to succeed, got %v", symmetry, err)
+		}
+
+		for i, row := range cw.Data {
+			for j, value := range row {
+				mirrorI, mirrorJ := 4-i, 4-j
+				if symmetry == crossword.MIRROR {
+					mirrorI = i
+				}
+
+				if (value == cw.Stop) != (cw.Data[mirrorI][mirrorJ] == cw.Stop) {
+					t.Errorf("Expected %v symmetry, got:\n%s", symmetry, cw.PrintData())
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package crossword
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+
+	"github.com/nitzanhen/crossword/src/structure"
+)
+
+// The default number of failed states a builder remembers.
+const DEFAULT_NOGOOD_CAPACITY = 100_000
+
+// A transposition table of search states known to fail ("no-goods"),
+// so that a state reached again through a different order of embeddings is not searched again.
+// Holds at most a fixed number of states, evicting the least recently used.
+type nogoodTable struct {
+	// Maps the key of each failed state to the cuts of the embeddings that caused the failure.
+	states *structure.LRU[uint64, []Cut]
+}
+
+func newNogoodTable(capacity int) *nogoodTable {
+	return &nogoodTable{structure.NewLRU[uint64, []Cut](capacity)}
+}
+
+// Returns a canonical hash of a search state: the crossword's data, its embedded words and the cuts left to fill.
+// States reached through different orders of embeddings hash the same.
+func nogoodKey(cw *Crossword, cuts structure.Set[Cut]) uint64 {
+	hash := fnv.New64a()
+
+	write := func(s string) {
+		hash.Write([]byte(s))
+		hash.Write([]byte{0})
+	}
+	writeInt := func(n int) {
+		binary.Write(hash, binary.LittleEndian, int64(n))
+	}
+
+	for _, row := range cw.Data {
+		for _, value := range row {
+			write(value)
+		}
+	}
+
+	words := Map(cw.Embeddings, func(cutword CutWithWord) string { return string(cutword.Word) })
+	sort.Strings(words)
+	for _, word := range words {
+		write(word)
+	}
+
+	sorted := cuts.ToSlice()
+	sort.Slice(sorted, func(i, j int) bool { return compareCuts(sorted[i], sorted[j]) < 0 })
+	for _, cut := range sorted {
+		writeInt(cut.Row)
+		writeInt(cut.Col)
+		writeInt(int(cut.Orientation))
+		writeInt(cut.Len)
+	}
+
+	return hash.Sum64()
+}
+
+// Returns the conflict set recorded for the state with the given key, as indices into cw's embeddings,
+// and whether the state is known to fail.
+func (table *nogoodTable) get(cw *Crossword, key uint64) (structure.Set[int], bool) {
+	culprits, ok := table.states.Get(key)
+	if !ok {
+		return structure.Set[int]{}, false
+	}
+
+	conflict := structure.NewSet[int](len(culprits))
+	for _, cut := range culprits {
+		i := FirstIndex(cw.Embeddings, func(cutword CutWithWord) bool { return cutword.Cut == cut })
+		if i == -1 {
+			// Should not happen for equal states; blame everything to be safe
+			return structure.SetFromSlice(IndexArray(len(cw.Embeddings))), true
+		}
+
+		conflict.Add(i)
+	}
+
+	return conflict, true
+}
+
+// Records that the state with the given key fails, because of the embeddings in conflict.
+func (table *nogoodTable) add(cw *Crossword, key uint64, conflict structure.Set[int]) {
+	culprits := Map(conflict.ToSlice(), func(i int) Cut { return cw.Embeddings[i].Cut })
+
+	table.states.Set(key, culprits)
+}
+
+func (table *nogoodTable) size() int {
+	return table.states.Size()
+}
@@ -0,0 +1,131 @@
+package crossword
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Maps a word as it is written to the form the builder matches and embeds it in,
+// so that words differing only in ways a solver ignores (case, diacritics, spacing) fill cells alike.
+type Normalizer interface {
+	Normalize(word string) string
+}
+
+// Applies normalizers one after the other.
+type Pipeline []Normalizer
+
+func (pipeline Pipeline) Normalize(word string) string {
+	for _, normalizer := range pipeline {
+		word = normalizer.Normalize(word)
+	}
+
+	return word
+}
+
+// Composes characters and their combining marks into single code points where possible (Unicode NFC).
+type NFC struct{}
+
+func (NFC) Normalize(word string) string {
+	return norm.NFC.String(word)
+}
+
+// Decomposes characters into base characters and combining marks (Unicode NFD).
+type NFD struct{}
+
+func (NFD) Normalize(word string) string {
+	return norm.NFD.String(word)
+}
+
+// Folds case, so that words match regardless of it. Unlike lower-casing, folding also maps forms
+// such as the German ß and the Greek final sigma to the letters they match.
+type CaseFold struct{}
+
+func (CaseFold) Normalize(word string) string {
+	return cases.Fold().String(word)
+}
+
+// Removes spaces and dashes, including the Hebrew maqaf, so that phrases fill cells as one word.
+type DropSeparators struct{}
+
+func (DropSeparators) Normalize(word string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || unicode.Is(unicode.Pd, r) {
+			return -1
+		}
+		return r
+	}, word)
+}
+
+// Replaces the Hebrew final letter forms with their regular forms, as crosswords do not tell them apart.
+type HebrewFinalForms struct{}
+
+var hebrewFinalForms = strings.NewReplacer("ך", "כ", "ם", "מ", "ן", "נ", "ף", "פ", "ץ", "צ")
+
+func (HebrewFinalForms) Normalize(word string) string {
+	return hebrewFinalForms.Replace(word)
+}
+
+// Removes Hebrew vowel points (niqqud) and cantillation marks, leaving the letters they are written on.
+type StripNiqqud struct{}
+
+func (StripNiqqud) Normalize(word string) string {
+	// Letters with a dagesh or a dot have precomposed forms, which NFC does not compose into
+	stripped := strings.Map(func(r rune) rune {
+		if 0x0591 <= r && r <= 0x05C7 && unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return r
+	}, norm.NFD.String(word))
+
+	return norm.NFC.String(stripped)
+}
+
+// Removes the diacritics of Latin letters, such as accents and cedillas, leaving the letters they are written on.
+// Marks on letters of other scripts are kept.
+type StripDiacritics struct{}
+
+func (StripDiacritics) Normalize(word string) string {
+	latin := false
+
+	stripped := strings.Map(func(r rune) rune {
+		if !unicode.Is(unicode.Mn, r) {
+			latin = unicode.Is(unicode.Latin, r)
+			return r
+		}
+		if latin {
+			return -1
+		}
+		return r
+	}, norm.NFD.String(word))
+
+	return norm.NFC.String(stripped)
+}
+
+// Normalizes Hebrew words: strips their niqqud, joins phrases and replaces final letter forms.
+var Hebrew = Pipeline{NFC{}, StripNiqqud{}, DropSeparators{}, CaseFold{}, HebrewFinalForms{}}
+
+// Normalizes words of Latin-script languages such as English and French:
+// strips their diacritics, joins phrases and folds case.
+var Latin = Pipeline{NFC{}, StripDiacritics{}, DropSeparators{}, CaseFold{}}
+
+// Normalizes each of the words, leaving out those that normalize to nothing or to a word already listed.
+// Returns the normalized words in order, along with the form each was first written in.
+func NormalizeWords(words []string, normalizer Normalizer) ([]Word, map[Word]string) {
+	normalized := make([]Word, 0, len(words))
+	display := make(map[Word]string, len(words))
+
+	for _, written := range words {
+		word := Word(normalizer.Normalize(written))
+		if _, ok := display[word]; ok || word == "" {
+			continue
+		}
+
+		normalized = append(normalized, word)
+		display[word] = written
+	}
+
+	return normalized, display
+}
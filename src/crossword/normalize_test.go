@@ -0,0 +1,71 @@
+package crossword_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+)
+
+func TestNormalizers(t *testing.T) {
+	tests := []struct {
+		normalizer crossword.Normalizer
+		word       string
+		expected   string
+	}{
+		{crossword.HebrewFinalForms{}, "שלום", "שלומ"},
+		{crossword.HebrewFinalForms{}, "ךםןףץ", "כמנפצ"},
+		{crossword.NFC{}, "é", "é"},
+		{crossword.NFD{}, "é", "é"},
+		{crossword.StripNiqqud{}, "שָׁלוֹם", "שלום"},
+		{crossword.StripNiqqud{}, "שׁ", "ש"}, // A precomposed shin with a dot
+		{crossword.StripDiacritics{}, "Crème brûlée", "Creme brulee"},
+		{crossword.StripDiacritics{}, "façade", "facade"},
+		{crossword.StripDiacritics{}, "שָׁלוֹם", "שָׁלוֹם"},
+		{crossword.DropSeparators{}, "ice cream", "icecream"},
+		{crossword.DropSeparators{}, "bien-être", "bienêtre"},
+		{crossword.DropSeparators{}, "בית־ספר", "ביתספר"},
+		{crossword.CaseFold{}, "Straße", "strasse"},
+		{crossword.CaseFold{}, "ΣΟΦΟΣ", "σοφοσ"},
+		{crossword.Hebrew, "בֵּית־סֵפֶר", "ביתספר"},
+		{crossword.Hebrew, "שָׁלוֹם", "שלומ"},
+		{crossword.Latin, "Crème Brûlée", "cremebrulee"},
+		{crossword.Latin, "été", "ete"},
+		{crossword.Pipeline{}, "As Is", "As Is"},
+	}
+
+	for _, test := range tests {
+		if got := test.normalizer.Normalize(test.word); got != test.expected {
+			t.Errorf("%T: expected %q to normalize to %q, got %q", test.normalizer, test.word, test.expected, got)
+		}
+	}
+}
+
+func TestNormalizedCorpus(t *testing.T) {
+	written := []string{"Crème", "creme", "Brûlée", "-", "ice cream"}
+
+	words, display := crossword.NormalizeWords(written, crossword.Latin)
+	if expected := []crossword.Word{"creme", "brulee", "icecream"}; !reflect.DeepEqual(words, expected) {
+		t.Errorf("Expected words %v, got %v", expected, words)
+	}
+	if display["creme"] != "Crème" {
+		t.Errorf("Expected the form written first to be kept, got %q", display["creme"])
+	}
+
+	corpus := crossword.NewNormalizedCorpus(written, crossword.Latin)
+	if !corpus.Has("icecream") || corpus.Has("ice cream") {
+		t.Errorf("Expected the corpus to hold the normalized words")
+	}
+	if got := corpus.Display("icecream"); got != "ice cream" {
+		t.Errorf("Expected the written form %q, got %q", "ice cream", got)
+	}
+	if got := corpus.Display("unknown"); got != "unknown" {
+		t.Errorf("Expected words not in the corpus to be displayed as is, got %q", got)
+	}
+
+	// Words differing only in their final letters fill cells alike
+	builder := crossword.NewBuilderWithCorpus(2, 2, crossword.NewNormalizedCorpus([]string{"אם", "בן", "אב", "מן"}, crossword.Hebrew), false)
+	if cw := builder.Build(); cw == nil {
+		t.Errorf("Expected a crossword of the normalized words")
+	}
+}
@@ -0,0 +1,99 @@
+package crossword
+
+import (
+	"sort"
+	"strings"
+)
+
+// A run of two cells or more between stops, along with the number of the cell it is read from.
+// Word is the word embedded in the run, or "" if none is.
+type Entry struct {
+	Number int `json:"number"`
+	CutWithWord
+}
+
+// The clue numbers of a crossword, as printed and published.
+type Numbering struct {
+	Numbers [][]int `json:"numbers"` // The number of each cell, or 0 if no entry starts at it
+
+	Across []Entry `json:"across"`
+	Down   []Entry `json:"down"`
+}
+
+// Numbers the entries of the crossword the way standard crosswords do: every run of two cells or more
+// between stops is an entry, the cells entries start at are numbered from 1, in reading order in the crossword's direction,
+// and the across and down entries are listed by their numbers.
+// Shorter embeddings are not numbered, and runs without an embedding are listed without a word.
+func (cw *Crossword) Number() Numbering {
+	numbering := Numbering{
+		Numbers: MakeMatrix(cw.Height, cw.Width, func(i, j int) int { return 0 }),
+		Across:  []Entry{},
+		Down:    []Entry{},
+	}
+
+	embedded := map[Cut]Word{}
+	for _, cutword := range cw.Embeddings {
+		embedded[cutword.Cut] = cutword.Word
+	}
+
+	runs := cw.GetCuts()
+	for _, run := range runs {
+		row, col := cw.startOf(run)
+		numbering.Numbers[row][col] = -1
+	}
+
+	number := 0
+	for row := 0; row < cw.Height; row++ {
+		for k := 0; k < cw.Width; k++ {
+			col := k
+			if cw.Direction == RIGHT_TO_LEFT {
+				col = cw.Width - 1 - k
+			}
+
+			if numbering.Numbers[row][col] != 0 {
+				number++
+				numbering.Numbers[row][col] = number
+			}
+		}
+	}
+
+	for _, run := range runs {
+		row, col := cw.startOf(run)
+		entry := Entry{numbering.Numbers[row][col], CutWithWord{run, embedded[run]}}
+		if run.Orientation == HORIZONTAL {
+			numbering.Across = append(numbering.Across, entry)
+		} else {
+			numbering.Down = append(numbering.Down, entry)
+		}
+	}
+
+	for _, entries := range [][]Entry{numbering.Across, numbering.Down} {
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].Number < entries[j].Number })
+	}
+
+	return numbering
+}
+
+// Returns the across and down entries together, by their numbers; across entries come first among those sharing one.
+func (numbering Numbering) Entries() []Entry {
+	entries := append(append([]Entry{}, numbering.Across...), numbering.Down...)
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Number < entries[j].Number })
+
+	return entries
+}
+
+// Replaces the embeddings of the crossword with the words written in its entries, as read from a published crossword.
+// Entries holding empty cells are left out. The words need not be unique.
+func (cw *Crossword) EmbedEntries() {
+	cw.Embeddings = []CutWithWord{}
+
+	for _, entry := range cw.Number().Entries() {
+		data := cw.GetCutData(entry.Cut)
+		isEmpty := func(value string) bool { return value == cw.Empty }
+		if FirstIndex(data, isEmpty) != -1 {
+			continue
+		}
+
+		cw.Embeddings = append(cw.Embeddings, CutWithWord{entry.Cut, Word(strings.Join(data, ""))})
+	}
+}
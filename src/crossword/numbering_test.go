@@ -0,0 +1,92 @@
+package crossword_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+)
+
+func TestNumber(t *testing.T) {
+	cw := crossword.NewCrossword(3, 3)
+	cw.Embed(crossword.Cut{Row: 0, Col: 0, Orientation: crossword.HORIZONTAL, Len: 3}, "cat")
+	cw.Embed(crossword.Cut{Row: 2, Col: 0, Orientation: crossword.HORIZONTAL, Len: 3}, "bow")
+	cw.Embed(crossword.Cut{Row: 0, Col: 2, Orientation: crossword.VERTICAL, Len: 3}, "tow")
+	cw.Embed(crossword.Cut{Row: 0, Col: 0, Orientation: crossword.VERTICAL, Len: 3}, "cab")
+	cw.Data[1][1] = cw.Stop
+
+	words := func(entries []crossword.Entry) map[int]crossword.Word {
+		numbered := map[int]crossword.Word{}
+		for _, entry := range entries {
+			numbered[entry.Number] = entry.Word
+		}
+		return numbered
+	}
+
+	tests := []struct {
+		direction    crossword.Direction
+		numbers      [][]int
+		across, down map[int]crossword.Word
+	}{
+		{
+			crossword.LEFT_TO_RIGHT,
+			[][]int{{1, 0, 2}, {0, 0, 0}, {3, 0, 0}},
+			map[int]crossword.Word{1: "cat", 3: "bow"},
+			map[int]crossword.Word{1: "cab", 2: "tow"},
+		},
+		{
+			crossword.RIGHT_TO_LEFT,
+			[][]int{{2, 0, 1}, {0, 0, 0}, {0, 0, 3}},
+			map[int]crossword.Word{1: "cat", 3: "bow"},
+			map[int]crossword.Word{1: "tow", 2: "cab"},
+		},
+	}
+
+	for _, test := range tests {
+		cw.Direction = test.direction
+		numbering := cw.Number()
+
+		if !reflect.DeepEqual(numbering.Numbers, test.numbers) {
+			t.Errorf("%v: expected numbers %v, got %v", test.direction, test.numbers, numbering.Numbers)
+		}
+		if got := words(numbering.Across); !reflect.DeepEqual(got, test.across) {
+			t.Errorf("%v: expected across entries %v, got %v", test.direction, test.across, got)
+		}
+		if got := words(numbering.Down); !reflect.DeepEqual(got, test.down) {
+			t.Errorf("%v: expected down entries %v, got %v", test.direction, test.down, got)
+		}
+		if numbering.Across[0].Number > numbering.Across[1].Number {
+			t.Errorf("%v: expected across entries in order, got %v", test.direction, numbering.Across)
+		}
+	}
+
+	// Single cells are not entries, and runs are numbered whether or not a word is embedded in them
+	partial := crossword.NewCrossword(3, 2)
+	partial.Embed(crossword.Cut{Row: 0, Col: 0, Orientation: crossword.HORIZONTAL, Len: 2}, "ab")
+	partial.Embed(crossword.Cut{Row: 1, Col: 0, Orientation: crossword.HORIZONTAL, Len: 3}, "cde")
+	partial.Embed(crossword.Cut{Row: 0, Col: 0, Orientation: crossword.VERTICAL, Len: 2}, "ac")
+	partial.Embed(crossword.Cut{Row: 1, Col: 2, Orientation: crossword.VERTICAL, Len: 1}, "e")
+	partial.Data[0][2] = partial.Stop
+
+	numbering := partial.Number()
+	if expected := [][]int{{1, 2, 0}, {3, 0, 0}}; !reflect.DeepEqual(numbering.Numbers, expected) {
+		t.Errorf("Expected numbers %v, got %v", expected, numbering.Numbers)
+	}
+	if expected := (map[int]crossword.Word{1: "ab", 3: "cde"}); !reflect.DeepEqual(words(numbering.Across), expected) {
+		t.Errorf("Expected across entries %v, got %v", expected, words(numbering.Across))
+	}
+	if expected := (map[int]crossword.Word{1: "ac", 2: ""}); !reflect.DeepEqual(words(numbering.Down), expected) {
+		t.Errorf("Expected down entries %v, got %v", expected, words(numbering.Down))
+	}
+
+	builder := crossword.NewBuilder(2, 2, squareWords, false)
+	built := builder.Build()
+	if built == nil {
+		t.Fatalf("Expected the builder to succeed")
+	}
+
+	numbering = built.Number()
+	if len(numbering.Across)+len(numbering.Down) != len(built.Embeddings) {
+		t.Errorf("Expected every embedding to be numbered, got %v", numbering)
+	}
+}
@@ -19,3 +19,22 @@ func (o Orientation) String() string {
 
 	panic(fmt.Sprintf("Invalid Orientation %d", int(o)))
 }
+
+// The direction rows of a crossword are read in.
+type Direction int
+
+const (
+	LEFT_TO_RIGHT Direction = iota
+	RIGHT_TO_LEFT Direction = iota // As in Hebrew and Arabic grids
+)
+
+func (direction Direction) String() string {
+	switch direction {
+	case LEFT_TO_RIGHT:
+		return "ltr"
+	case RIGHT_TO_LEFT:
+		return "rtl"
+	}
+
+	panic(fmt.Sprintf("Invalid Direction %d", int(direction)))
+}
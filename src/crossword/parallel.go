@@ -0,0 +1,136 @@
+package crossword
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Creates a builder with the same grid, corpus and options, but its own search state and stats.
+// The corpus is shared rather than copied; the random source is not copied, leaving the clone deterministic.
+func (builder *Builder) clone() *Builder {
+	return &Builder{
+		width:  builder.width,
+		height: builder.height,
+		corpus: builder.corpus,
+		grid:   builder.grid,
+		debug:  builder.debug,
+
+		direction: builder.direction,
+
+		themeWords: builder.themeWords,
+		freeThemes: builder.freeThemes,
+		template:   builder.template,
+		symmetry:   builder.symmetry,
+
+		minWordLength: builder.minWordLength,
+		maxStopShare:  builder.maxStopShare,
+		connected:     builder.connected,
+		maxUnchecked:  builder.maxUnchecked,
+
+		cutOrder:     builder.cutOrder,
+		wordOrder:    builder.wordOrder,
+		candidateCap: builder.candidateCap,
+		complete:     builder.complete,
+
+		regexMatching:  builder.regexMatching,
+		propagation:    builder.propagation,
+		backjumping:    builder.backjumping,
+		restarts:       builder.restarts,
+		nogoodCapacity: builder.nogoodCapacity,
+
+		listener: builder.listener,
+	}
+}
+
+// Creates a clone of the builder (see clone) with its own seed and word order.
+func (builder *Builder) fork(seed int64, wordOrder WordOrder) *Builder {
+	fork := builder.clone()
+	fork.SetSeed(seed)
+	fork.SetWordOrder(wordOrder)
+
+	return fork
+}
+
+// The word orders BuildParallel's searches take turns using, after the builder's own.
+// CorpusOrder is there for builders with another order: seeded, it tries words in a random order.
+var wordOrders = []WordOrder{LeastConstraining{}, CorpusOrder{}}
+
+type parallelResult struct {
+	worker *Builder
+	cw     *Crossword
+	err    error
+}
+
+// Runs n independent searches on separate goroutines, and returns the first crossword completed by any of them.
+// Each search is seeded differently, and the searches take turns ranking words by the builder's word order,
+// by LeastConstraining and by CorpusOrder, so that they try cuts and words in different orders;
+// the rest are cancelled once one completes, or when ctx is done.
+//
+// On success, the builder takes on the seed and word order of the winning search,
+// so that a following call to BuildContext reproduces the same crossword.
+func (builder *Builder) BuildParallel(ctx context.Context, n int) (*Crossword, error) {
+	if n < 1 {
+		n = 1
+	}
+
+	// Fail once, rather than in every search
+	if _, _, err := builder.start(ctx); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	base, ok := builder.Seed()
+	if !ok {
+		base = time.Now().UnixNano()
+	}
+
+	orders := append([]WordOrder{builder.wordOrder}, wordOrders...)
+
+	workers := make([]*Builder, n)
+	for i := range workers {
+		workers[i] = builder.fork(base+int64(i), orders[i%len(orders)])
+	}
+
+	builder.stats.reset(builder.corpus)
+	defer builder.stats.finish()
+
+	builder.mu.Lock()
+	builder.workers = workers
+	builder.mu.Unlock()
+
+	// Buffered, so that searches finishing after the winner never block
+	results := make(chan parallelResult, n)
+	for _, worker := range workers {
+		go func(worker *Builder) {
+			cw, err := worker.BuildContext(ctx)
+			results <- parallelResult{worker, cw, err}
+		}(worker)
+	}
+
+	noSolution := &NoSolutionError{}
+	var err error = noSolution
+
+	for range workers {
+		result := <-results
+
+		if result.err == nil {
+			seed, _ := result.worker.Seed()
+			builder.SetSeed(seed)
+			builder.SetWordOrder(result.worker.wordOrder)
+
+			return result.cw, nil
+		}
+
+		var workerNoSolution *NoSolutionError
+		if errors.As(result.err, &workerNoSolution) {
+			noSolution.Capped = noSolution.Capped || workerNoSolution.Capped
+		} else {
+			err = result.err
+		}
+	}
+
+	return nil, err
+}
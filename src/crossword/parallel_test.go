@@ -0,0 +1,47 @@
+package crossword_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+)
+
+func TestBuildParallel(t *testing.T) {
+	words := readWords(t)
+
+	builder := crossword.NewBuilder(4, 4, words, false)
+	cw, err := builder.BuildParallel(context.Background(), 4)
+	if err != nil {
+		t.Fatalf("Expected builder.BuildParallel() to succeed, got %v", err)
+	}
+
+	if stats := builder.Stats(); stats.Calls == 0 {
+		t.Errorf("Expected the workers' calls to be counted, got %+v", stats)
+	}
+
+	// The winning seed is kept, so a sequential build reproduces the result
+	reproduced, err := builder.BuildContext(context.Background())
+	if err != nil || reproduced.PrintData() != cw.PrintData() {
+		t.Errorf("Expected a sequential build to reproduce\n%s\ngot\n%v (%v)", cw.PrintData(), reproduced, err)
+	}
+
+	// The sequential build's stats leave out the parallel searches before it
+	seed, _ := builder.Seed()
+	fresh := crossword.NewBuilder(4, 4, words, false)
+	fresh.SetSeed(seed)
+	fresh.BuildContext(context.Background())
+	if calls, expected := builder.Stats().Calls, fresh.Stats().Calls; calls != expected {
+		t.Errorf("Expected the sequential build's %d calls alone, got %d", expected, calls)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	builder = crossword.NewBuilder(12, 12, words, false)
+	if _, err := builder.BuildParallel(ctx, 4); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package crossword
+
+import (
+	"github.com/nitzanhen/crossword/src/structure"
+)
+
+// A word at a given offset within a cut.
+type placement struct {
+	word   Word
+	chars  []string
+	offset int
+}
+
+// Returns what embedding the placement would write to the k-th cell of its cut:
+// a letter, the stop before or after the word, or "" if the cell is left untouched.
+func (p *placement) valueAt(k int, stop string) string {
+	end := p.offset + len(p.chars)
+
+	switch {
+	case p.offset <= k && k < end:
+		return p.chars[k-p.offset]
+	case k == p.offset-1 || k == end:
+		return stop
+	}
+
+	return ""
+}
+
+// Returns all valid placements of the given words in cut, i.e. those isValidOffset accepts.
+func (builder *Builder) getPlacements(cw *Crossword, cut Cut, words []Word) []placement {
+	data := cw.GetCutData(cut)
+	isLetter := func(k int) bool {
+		return 0 <= k && k < len(data) && data[k] != cw.Empty && data[k] != cw.Stop
+	}
+
+	placements := []placement{}
+
+	for _, word := range words {
+		chars := builder.corpus.charsOf(word)
+		for offset := 0; offset+len(chars) <= cut.Len; offset++ {
+			// The cells around the word must not hold letters.
+			// Those outside the cut are either stops or out of bounds.
+			if isLetter(offset-1) || isLetter(offset+len(chars)) {
+				continue
+			}
+
+			if !fitsChars(data[offset:offset+len(chars)], chars, cw.Empty) {
+				continue
+			}
+			if !builder.allowsPlacement(cw, cw.Subcut(cut, offset, offset+len(chars))) {
+				continue
+			}
+
+			placements = append(placements, placement{word, chars, offset})
+		}
+	}
+
+	return placements
+}
+
+// Returns the distinct words of the given placements, in order of first appearance.
+func distinctWords(placements []placement) []Word {
+	words := structure.NewSet[Word](len(placements))
+	for _, p := range placements {
+		words.Add(p.word)
+	}
+
+	return words.ToSlice()
+}
+
+// Returns the offsets at which word is placed among the given placements.
+func offsetsOf(placements []placement, word Word) []int {
+	return Map(
+		Filter(placements, func(p placement) bool { return p.word == word }),
+		func(p placement) int { return p.offset },
+	)
+}
+
+// Returns the index of the cell where the two (meeting, differently oriented) cuts cross,
+// within the first cut in reading order.
+func (cw *Crossword) crossingIndex(cut, other Cut) int {
+	if cut.Orientation == HORIZONTAL {
+		if cw.Direction == RIGHT_TO_LEFT {
+			return cut.Col + cut.Len - 1 - other.Col
+		}
+		return other.Col - cut.Col
+	}
+
+	return other.Row - cut.Row
+}
+
+// Removes the placements of cut that cannot coexist with any placement of other, where the two cross.
+// Returns whether any placement was removed.
+//
+// A placement writing a letter to the crossing cell needs a placement of other that writes the same letter there,
+// and one writing a stop needs another stop; either is also satisfied by a placement of other
+// that leaves the cell untouched, as it may still be filled by a later word. Placements that leave the cell
+// untouched themselves are always kept.
+func (builder *Builder) revise(cw *Crossword, domains *structure.OrderedMap[Cut, []placement], cut, other Cut) bool {
+	k, otherK := cw.crossingIndex(cut, other), cw.crossingIndex(other, cut)
+
+	supported := make(map[string]bool)
+	for _, p := range domains.Get(other) {
+		supported[p.valueAt(otherK, cw.Stop)] = true
+	}
+
+	domain := domains.Get(cut)
+	revised := make([]placement, 0, len(domain))
+	for _, p := range domain {
+		if value := p.valueAt(k, cw.Stop); value == "" || supported[value] || supported[""] {
+			revised = append(revised, p)
+		}
+	}
+
+	if len(revised) == len(domain) {
+		return false
+	}
+
+	domains.Set(cut, revised)
+	return true
+}
+
+// Enforces arc consistency between the domains of crossing cuts, AC-3 style:
+// placements are pruned until each remaining one is supported by some placement of every cut it crosses.
+// Returns false, along with the cut, if some cut is left without placements; in that case the state cannot be completed.
+//
+// reasons holds the culprits (see culpritsOf) behind each cut's domain.
+// Whenever a domain is pruned against that of a crossing cut, it inherits the crossing cut's reasons.
+func (builder *Builder) propagate(
+	cw *Crossword,
+	domains *structure.OrderedMap[Cut, []placement],
+	reasons *structure.OrderedMap[Cut, structure.Set[int]],
+) (Cut, bool) {
+	cuts := structure.SetFromSlice(domains.Keys())
+	graph := GetCutGraph(cw, &cuts)
+
+	type arc struct{ cut, other Cut }
+
+	neighborsOf := func(cut Cut) []Cut {
+		neighbors := graph.Neighborhood.Get(cut)
+		return Filter(neighbors.ToSlice(), func(other Cut) bool { return other != cut })
+	}
+
+	queue := structure.List[arc]{}
+	queued := structure.NewSet[arc](0)
+	enqueue := func(a arc) {
+		if !queued.Has(a) {
+			queued.Add(a)
+			queue.Add(a)
+		}
+	}
+
+	for _, cut := range cuts.ToSlice() {
+		for _, other := range neighborsOf(cut) {
+			enqueue(arc{cut, other})
+		}
+	}
+
+	for queue.Size() > 0 {
+		a := queue.Shift()
+		queued.Delete(a)
+
+		if !builder.revise(cw, domains, a.cut, a.other) {
+			continue
+		}
+
+		cutReasons, otherReasons := reasons.Get(a.cut), reasons.Get(a.other)
+		cutReasons.Union(&otherReasons)
+
+		if len(domains.Get(a.cut)) == 0 {
+			return a.cut, false
+		}
+
+		// The cut's domain shrank, so the cuts it crosses must be revised against it again
+		for _, other := range neighborsOf(a.cut) {
+			if other != a.other {
+				enqueue(arc{other, a.cut})
+			}
+		}
+	}
+
+	return Cut{}, true
+}
@@ -0,0 +1,54 @@
+package crossword
+
+import "strings"
+
+// A clue for the entry in the cut.
+type Clue struct {
+	Cut  Cut
+	Text string
+}
+
+// A crossword along with its clues and metadata, as published.
+type Puzzle struct {
+	Crossword *Crossword
+
+	Title     string
+	Author    string
+	Copyright string
+	Notes     string
+
+	// Clues by entry. Entries without a clue are published with an empty one.
+	Clues []Clue
+}
+
+// Creates a puzzle of cw, clueing each of its entries with the clue of its word, if any.
+// Entries are those of cw as published, with its empty cells as stops (see Completed);
+// those without an embedded word are clued by the letters written in them.
+func NewPuzzle(cw *Crossword, clues map[Word]string) *Puzzle {
+	puzzle := &Puzzle{Crossword: cw}
+
+	completed := cw.Completed()
+	for _, entry := range completed.Number().Entries() {
+		word := entry.Word
+		if word == "" {
+			word = Word(strings.Join(completed.GetCutData(entry.Cut), ""))
+		}
+
+		if text, ok := clues[word]; ok {
+			puzzle.Clues = append(puzzle.Clues, Clue{entry.Cut, text})
+		}
+	}
+
+	return puzzle
+}
+
+// Returns the text of the clue for the entry in the cut, or "" if it has none.
+func (puzzle *Puzzle) ClueOf(cut Cut) string {
+	for _, clue := range puzzle.Clues {
+		if clue.Cut == cut {
+			return clue.Text
+		}
+	}
+
+	return ""
+}
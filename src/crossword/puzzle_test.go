@@ -0,0 +1,36 @@
+package crossword_test
+
+import (
+	"testing"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+)
+
+func TestNewPuzzle(t *testing.T) {
+	cw := crossword.NewCrossword(2, 2)
+	cw.Embed(crossword.Cut{Row: 0, Col: 0, Orientation: crossword.HORIZONTAL, Len: 2}, "ab")
+	cw.Embed(crossword.Cut{Row: 1, Col: 0, Orientation: crossword.HORIZONTAL, Len: 2}, "cd")
+
+	// The down entries hold no embedding, and are clued by their letters
+	puzzle := crossword.NewPuzzle(&cw, map[crossword.Word]string{"ab": "First", "bd": "Second", "xy": "Unused"})
+	if len(puzzle.Clues) != 2 {
+		t.Errorf("Expected 2 clues, got %v", puzzle.Clues)
+	}
+	if text := puzzle.ClueOf(crossword.Cut{Row: 0, Col: 1, Orientation: crossword.VERTICAL, Len: 2}); text != "Second" {
+		t.Errorf("Expected the unembedded entry to be clued, got %q", text)
+	}
+	if text := puzzle.ClueOf(crossword.Cut{Row: 1, Col: 0, Orientation: crossword.HORIZONTAL, Len: 2}); text != "" {
+		t.Errorf("Expected no clue for cd, got %q", text)
+	}
+
+	cw.EmbedEntries()
+	if len(cw.Embeddings) != 4 || !cw.IsWordEmbedded("ac") || !cw.IsWordEmbedded("bd") {
+		t.Errorf("Expected every entry to be embedded, got %v", cw.Embeddings)
+	}
+
+	cw.Data[1][1] = cw.Empty
+	cw.EmbedEntries()
+	if len(cw.Embeddings) != 2 || cw.IsWordEmbedded("bd") {
+		t.Errorf("Expected entries with empty cells to be left out, got %v", cw.Embeddings)
+	}
+}
@@ -0,0 +1,150 @@
+package crossword
+
+import (
+	"github.com/nitzanhen/crossword/src/structure"
+)
+
+// The shortest word a builder places by default.
+// Single letters are only placed in cuts of two cells or more, though; runs of a single cell are left unchecked.
+const DEFAULT_MIN_WORD_LENGTH = 1
+
+// Sets the length of the shortest word the builder places, which defaults to DEFAULT_MIN_WORD_LENGTH.
+// Cuts shorter than that are not filled, and no run of two letters or more may be shorter than it.
+func (builder *Builder) SetMinWordLength(length int) {
+	builder.minWordLength = Max(length, DEFAULT_MIN_WORD_LENGTH)
+}
+
+// Sets the largest share of the grid's cells that may be stops, between 0 and 1.
+// Cells left empty once the crossword is complete count as stops. A share >= 1 removes the limit.
+func (builder *Builder) SetMaxStopShare(share float64) {
+	builder.maxStopShare = share
+}
+
+// Makes the builder require that the letter cells of every crossword form a single region, connected across edges.
+func (builder *Builder) SetConnected(enabled bool) {
+	builder.connected = enabled
+}
+
+// Sets the largest number of unchecked cells, i.e. letters that belong to a single word, a crossword may have.
+// A negative limit removes it.
+func (builder *Builder) SetMaxUnchecked(limit int) {
+	builder.maxUnchecked = limit
+}
+
+// Checks and returns whether any of the quality constraints is set.
+func (builder *Builder) hasQualityConstraints() bool {
+	return builder.minWordLength > 2 ||
+		builder.maxStopShare < 1 ||
+		builder.connected ||
+		builder.maxUnchecked >= 0
+}
+
+// Returns the subcuts of the given cuts that are long enough to hold a word (see SetMinWordLength).
+func (builder *Builder) subcutsOf(cw *Crossword, cuts []Cut) []Cut {
+	return cw.SubcutsOfMinLength(cuts, Max(builder.minWordLength, 2))
+}
+
+// Checks whether cw may still be completed to a crossword meeting the builder's quality constraints.
+// Stops are never removed and letters never changed, so a crossword that breaks them can be pruned from the search.
+//
+// If complete, cw is final: its empty cells will never be filled, so they are taken as stops.
+// Otherwise they may still become either, so they are only held against cw where it cannot fail otherwise.
+func (builder *Builder) meetsQuality(cw *Crossword, complete bool) bool {
+	if !builder.hasQualityConstraints() {
+		return true
+	}
+
+	isLetter := func(i, j int) bool {
+		return cw.IsValid(i, j) && cw.Data[i][j] != cw.Empty && cw.Data[i][j] != cw.Stop
+	}
+	isBlocked := func(i, j int) bool {
+		return !cw.IsValid(i, j) || cw.Data[i][j] == cw.Stop || (complete && cw.Data[i][j] == cw.Empty)
+	}
+
+	blocked, unchecked := 0, 0
+	letters := [][2]int{}
+
+	for i, row := range cw.Data {
+		for j := range row {
+			if isBlocked(i, j) {
+				blocked++
+				continue
+			}
+			if !isLetter(i, j) {
+				continue
+			}
+
+			letters = append(letters, [2]int{i, j})
+
+			for _, o := range []Orientation{HORIZONTAL, VERTICAL} {
+				preI, preJ := Move(i, j, o, -1)
+				postI, postJ := Move(i, j, o, 1)
+				if isBlocked(preI, preJ) && isBlocked(postI, postJ) {
+					unchecked++
+					break
+				}
+			}
+		}
+	}
+
+	if builder.maxStopShare < 1 && float64(blocked) > builder.maxStopShare*float64(cw.Width*cw.Height) {
+		return false
+	}
+	if builder.maxUnchecked >= 0 && unchecked > builder.maxUnchecked {
+		return false
+	}
+
+	if builder.minWordLength > 2 {
+		// Two adjacent letters in a run of open cells shorter than the minimum form a word too short
+		lines := append(
+			Map(IndexArray(cw.Height), func(row int) Cut { return Cut{row, 0, HORIZONTAL, cw.Width} }),
+			Map(IndexArray(cw.Width), func(col int) Cut { return Cut{0, col, VERTICAL, cw.Height} })...,
+		)
+
+		for _, line := range lines {
+			start, adjacent := 0, false
+
+			for k := 0; k <= line.Len; k++ {
+				i, j := Move(line.Row, line.Col, line.Orientation, k)
+				if !isBlocked(i, j) {
+					prevI, prevJ := Move(i, j, line.Orientation, -1)
+					adjacent = adjacent || (k > start && isLetter(i, j) && isLetter(prevI, prevJ))
+					continue
+				}
+
+				if adjacent && k-start < builder.minWordLength {
+					return false
+				}
+				start, adjacent = k+1, false
+			}
+		}
+	}
+
+	if builder.connected && len(letters) > 1 {
+		// All letters must be reachable from the first through cells that are not blocked
+		reached := structure.NewSet[[2]int](len(letters))
+		reached.Add(letters[0])
+		queue := [][2]int{letters[0]}
+
+		for len(queue) > 0 {
+			cell := queue[0]
+			queue = queue[1:]
+
+			for _, step := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+				next := [2]int{cell[0] + step[0], cell[1] + step[1]}
+				if !isBlocked(next[0], next[1]) && !reached.Has(next) {
+					reached.Add(next)
+					queue = append(queue, next)
+				}
+			}
+		}
+
+		for _, cell := range letters {
+			if !reached.Has(cell) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,107 @@
+package crossword_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+)
+
+func TestQualityConstraints(t *testing.T) {
+	words := []crossword.Word{"bb", "ac", "ccb", "acb", "bc"}
+
+	// Both crosswords have 3 stops and 3 unchecked letters
+	tests := []struct {
+		name  string
+		apply func(builder *crossword.Builder)
+		count int
+	}{
+		{"none", func(builder *crossword.Builder) {}, 2},
+		{"max stop share", func(builder *crossword.Builder) { builder.SetMaxStopShare(0.3) }, 0},
+		{"loose max stop share", func(builder *crossword.Builder) { builder.SetMaxStopShare(0.4) }, 2},
+		{"max unchecked", func(builder *crossword.Builder) { builder.SetMaxUnchecked(2) }, 0},
+		{"loose max unchecked", func(builder *crossword.Builder) { builder.SetMaxUnchecked(3) }, 2},
+		{"min word length", func(builder *crossword.Builder) { builder.SetMinWordLength(3) }, 0},
+		{"connected", func(builder *crossword.Builder) { builder.SetConnected(true) }, 2},
+	}
+
+	for _, test := range tests {
+		builder := crossword.NewBuilder(3, 3, words, false)
+		test.apply(builder)
+
+		if count, _ := builder.Count(context.Background()); count != test.count {
+			t.Errorf("%s: expected %d crosswords, got %d", test.name, test.count, count)
+		}
+	}
+}
+
+func TestQualityBuild(t *testing.T) {
+	words := readWords(t)
+
+	builder := crossword.NewBuilder(5, 5, shuffleWords(words, 2), false)
+	builder.SetSeed(2)
+	builder.SetRestarts(crossword.LubyRestarts(100))
+	builder.SetMinWordLength(3)
+	builder.SetMaxStopShare(0.25)
+	builder.SetConnected(true)
+	builder.SetMaxUnchecked(2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cw, err := builder.BuildContext(ctx)
+	if err != nil {
+		t.Fatalf("Expected builder.BuildContext() to succeed, got %v", err)
+	}
+
+	isLetter := func(i, j int) bool {
+		return cw.IsValid(i, j) && cw.Data[i][j] != cw.Empty && cw.Data[i][j] != cw.Stop
+	}
+
+	stops, unchecked := 0, 0
+	for i, row := range cw.Data {
+		for j := range row {
+			if !isLetter(i, j) {
+				stops++
+			} else if !(isLetter(i-1, j) || isLetter(i+1, j)) || !(isLetter(i, j-1) || isLetter(i, j+1)) {
+				unchecked++
+			}
+		}
+	}
+	if stops > 6 || unchecked > 2 {
+		t.Errorf("Expected at most 6 stops and 2 unchecked cells, got %d and %d:\n%s", stops, unchecked, cw.PrintData())
+	}
+
+	for _, cutword := range cw.Embeddings {
+		if len(cutword.Word) < 3 {
+			t.Errorf("Expected words of 3 letters or more, got %v", cutword.Word)
+		}
+	}
+
+	// Runs of letters, across and down
+	for _, grid := range []string{cw.PrintData(), transpose(cw).PrintData()} {
+		for _, row := range strings.Split(grid, "\n") {
+			row = strings.NewReplacer(" ", "", "|", "").Replace(row)
+			isBlank := func(r rune) bool { return string(r) == cw.Stop || string(r) == cw.Empty }
+			for _, run := range strings.FieldsFunc(row, isBlank) {
+				if len(run) == 2 {
+					t.Errorf("Expected no runs of 2 letters, got %v in:\n%s", run, cw.PrintData())
+				}
+			}
+		}
+	}
+}
+
+// Returns a copy of the crossword's data, flipped over its diagonal.
+func transpose(cw *crossword.Crossword) *crossword.Crossword {
+	flipped := crossword.NewCrossword(cw.Height, cw.Width)
+	for i, row := range cw.Data {
+		for j, value := range row {
+			flipped.Data[j][i] = value
+		}
+	}
+
+	return &flipped
+}
@@ -0,0 +1,44 @@
+package crossword
+
+import (
+	"errors"
+	"math"
+)
+
+// Returned by search when the current attempt has used up its calls, and the build should restart.
+var errRestart = errors.New("restart")
+
+// Returns how many search states the given attempt of a build (starting at 0) may visit before it is restarted.
+type RestartSchedule func(attempt int) int
+
+// Restarts after unit times the i-th element of the Luby sequence (1, 1, 2, 1, 1, 2, 4, 1, 1, 2, ...).
+// The Luby schedule is within a log factor of the optimal schedule for any distribution of run times.
+func LubyRestarts(unit int) RestartSchedule {
+	return func(attempt int) int {
+		return unit * luby(attempt+1)
+	}
+}
+
+// Restarts after initial calls, multiplying the cutoff by factor on every attempt.
+func GeometricRestarts(initial int, factor float64) RestartSchedule {
+	return func(attempt int) int {
+		cutoff := float64(initial) * math.Pow(factor, float64(attempt))
+		if cutoff > math.MaxInt32 {
+			return math.MaxInt32
+		}
+
+		return int(cutoff)
+	}
+}
+
+// Returns the i-th element (starting at 1) of the Luby sequence.
+func luby(i int) int {
+	for k := 1; ; k++ {
+		if i == (1<<k)-1 {
+			return 1 << (k - 1)
+		}
+		if i < (1<<k)-1 {
+			return luby(i - (1 << (k - 1)) + 1)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package crossword
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+)
+
+// Returned by search when the enumeration's callback asks to stop.
+var errStop = errors.New("stop")
+
+// Returns a hash of the crossword's data, which distinguishes different fills of the grid.
+func dataKey(cw *Crossword) uint64 {
+	hash := fnv.New64a()
+	for _, row := range cw.Data {
+		for _, value := range row {
+			hash.Write([]byte(value))
+			hash.Write([]byte{0})
+		}
+	}
+
+	return hash.Sum64()
+}
+
+// Searches the entire search space, calling fn with every distinct crossword found, until fn returns false.
+// Returns an error if the grid could not be set up (e.g. for the theme words), or if ctx is done before then.
+//
+// The search is always complete (see SetComplete) and never restarts. It runs on a clone of the builder,
+// so that enumerations may run alongside each other and alongside builds; the clone keeps the builder's seed, if set,
+// and its stats aren't reported by Stats.
+func (builder *Builder) enumerate(ctx context.Context, fn func(cw *Crossword) bool) error {
+	search := builder.clone()
+	if seed, ok := builder.Seed(); ok {
+		search.SetSeed(seed)
+	}
+
+	cw, cuts, err := search.start(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The same crossword may be completed through different orders of embeddings.
+	// Telling them apart keeps a hash of every crossword found, which grows with the number of solutions.
+	seen := make(map[uint64]bool)
+
+	search.yield = func(cw *Crossword) bool {
+		key := dataKey(cw)
+		if seen[key] {
+			return true
+		}
+		seen[key] = true
+
+		return fn(cw)
+	}
+
+	search.begin()
+	defer search.stats.finish()
+
+	_, _, err = search.build(ctx, &cw, cuts)
+	if errors.Is(err, errStop) {
+		return nil
+	}
+
+	return err
+}
+
+// Enumerates distinct crosswords, sending each on the returned channel as it is found.
+// The channel is closed once limit crosswords were sent (no limit if limit <= 0), once every crossword was found,
+// or once ctx is done; a consumer that stops reading early must cancel ctx to end the search.
+//
+// Once the channel is closed, the returned function reports why the enumeration ended early: the context's error,
+// or an error setting up the grid (e.g. for the theme words). It returns nil if the enumeration ended normally.
+//
+// The search is complete (see SetComplete), so without a limit the channel eventually carries every crossword
+// the search reaches; as with a complete build, those that need a cut split after no word matched it are not reached.
+func (builder *Builder) Solutions(ctx context.Context, limit int) (<-chan *Crossword, func() error) {
+	solutions := make(chan *Crossword)
+
+	var err error
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer close(solutions)
+
+		found := 0
+		err = builder.enumerate(ctx, func(cw *Crossword) bool {
+			copy := cw.Copy()
+
+			select {
+			case solutions <- &copy:
+				found++
+				return limit <= 0 || found < limit
+			case <-ctx.Done():
+				return false
+			}
+		})
+
+		if err == nil && (limit <= 0 || found < limit) {
+			err = ctx.Err()
+		}
+	}()
+
+	return solutions, func() error {
+		<-done
+		return err
+	}
+}
+
+// Counts the distinct crosswords that can be built. The crosswords themselves aren't kept,
+// but telling them apart keeps a hash of each (see Solutions).
+// If ctx is done before the search ends, returns the count so far along with the context's error.
+func (builder *Builder) Count(ctx context.Context) (int, error) {
+	count := 0
+	err := builder.enumerate(ctx, func(cw *Crossword) bool {
+		count++
+		return true
+	})
+
+	return count, err
+}
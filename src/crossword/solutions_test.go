@@ -0,0 +1,94 @@
+package crossword_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+)
+
+func TestSolutions(t *testing.T) {
+	builder := crossword.NewBuilder(2, 2, squareWords, false)
+
+	// ab/cd, and its transpose ac/bd
+	count, err := builder.Count(context.Background())
+	if err != nil || count != 2 {
+		t.Errorf("Expected builder.Count() = (2, nil), got (%d, %v)", count, err)
+	}
+
+	seen := map[string]bool{}
+	all, allErr := builder.Solutions(context.Background(), 0)
+	for cw := range all {
+		seen[cw.PrintData()] = true
+	}
+	if len(seen) != 2 || allErr() != nil {
+		t.Errorf("Expected 2 distinct solutions and no error, got %d and %v", len(seen), allErr())
+	}
+
+	found := 0
+	first, firstErr := builder.Solutions(context.Background(), 1)
+	for range first {
+		found++
+	}
+	if found != 1 || firstErr() != nil {
+		t.Errorf("Expected a limit of 1 to yield 1 solution and no error, got %d and %v", found, firstErr())
+	}
+
+	// Both solutions leave a column split into single cells, which no word fills
+	sparse := crossword.NewBuilder(3, 3, []crossword.Word{"bb", "ac", "ccb", "acb", "bc"}, false)
+	if count, _ := sparse.Count(context.Background()); count != 2 {
+		t.Errorf("Expected 2 solutions leaving cuts without words, got %d", count)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := builder.Count(ctx); err != context.Canceled {
+		t.Errorf("Expected a cancelled count to return context.Canceled, got %v", err)
+	}
+	cancelled, cancelledErr := builder.Solutions(ctx, 0)
+	for range cancelled {
+	}
+	if err := cancelledErr(); err != context.Canceled {
+		t.Errorf("Expected cancelled solutions to report context.Canceled, got %v", err)
+	}
+
+	// No word fits the theme, so the grid can't be set up
+	themed := crossword.NewBuilder(2, 2, squareWords, false)
+	themed.SetThemeWords([]crossword.ThemeWord{{Word: "xyz"}})
+	themedSolutions, themedErr := themed.Solutions(context.Background(), 0)
+	for range themedSolutions {
+		t.Errorf("Expected no solutions with a theme word that doesn't fit")
+	}
+	if themedErr() == nil {
+		t.Errorf("Expected solutions to report the theme error")
+	}
+}
+
+func TestSolutionsConcurrently(t *testing.T) {
+	builder := crossword.NewBuilder(2, 2, squareWords, false)
+	builder.SetSeed(1)
+
+	// Enumerations run on their own copy of the builder, so they may run alongside each other and a build
+	var wg sync.WaitGroup
+	counts := make([]int, 4)
+	for i := range counts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			counts[i], _ = builder.Count(context.Background())
+		}(i)
+	}
+	cw := builder.Build()
+	wg.Wait()
+
+	if cw == nil {
+		t.Errorf("Expected a build alongside enumerations to succeed")
+	}
+	for _, count := range counts {
+		if count != 2 {
+			t.Errorf("Expected every concurrent count to be 2, got %v", counts)
+			break
+		}
+	}
+}
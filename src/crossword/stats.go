@@ -0,0 +1,130 @@
+package crossword
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// A snapshot of a builder's search progress.
+type Stats struct {
+	Calls      int `json:"calls"`      // Number of search states visited.
+	Failures   int `json:"failures"`   // Number of search states found to be dead ends.
+	Backtracks int `json:"backtracks"` // Number of embeddings that were undone.
+	Backjumps  int `json:"backjumps"`  // Number of times the search skipped back over levels not to blame for a failure.
+	Restarts   int `json:"restarts"`   // Number of attempts abandoned by the restart schedule.
+	Depth      int `json:"depth"`      // Number of nested embeddings in the state currently being searched.
+	NogoodHits int `json:"nogoodHits"` // Number of states skipped as already known to fail.
+	Nogoods    int `json:"nogoods"`    // Number of failed states currently remembered.
+
+	BestFill     float64 `json:"bestFill"`     // Largest share of filled-in cells reached so far, between 0 and 1.
+	CacheHitRate float64 `json:"cacheHitRate"` // Share of the build's corpus lookups answered from the cache, between 0 and 1.
+
+	Elapsed time.Duration `json:"elapsed"`
+}
+
+// Live counters of a build, written by the search and read by Builder.Stats.
+// All fields are accessed atomically, so the two may run on different goroutines.
+type buildStats struct {
+	calls, failures, backtracks atomic.Int64
+	backjumps, restarts         atomic.Int64
+	depth                       atomic.Int64
+	nogoodHits, nogoods         atomic.Int64
+	bestFill                    atomic.Int64 // Number of filled-in cells
+	start, end                  atomic.Int64 // Unix nanoseconds, 0 if unset
+
+	// The corpus' cache counters when the build began, which the build's hit rate is measured from.
+	cacheHits, cacheMisses atomic.Int64
+}
+
+// Returns a snapshot of the counters, for a crossword with the given number of cells.
+// CacheHitRate is left for the caller to fill in.
+func (stats *buildStats) snapshot(cells int) Stats {
+	return Stats{
+		Calls:      int(stats.calls.Load()),
+		Failures:   int(stats.failures.Load()),
+		Backtracks: int(stats.backtracks.Load()),
+		Backjumps:  int(stats.backjumps.Load()),
+		Restarts:   int(stats.restarts.Load()),
+		Depth:      int(stats.depth.Load()),
+		NogoodHits: int(stats.nogoodHits.Load()),
+		Nogoods:    int(stats.nogoods.Load()),
+		BestFill:   float64(stats.bestFill.Load()) / float64(cells),
+		Elapsed:    stats.elapsed(),
+	}
+}
+
+// Merges the stats of a concurrent search into these:
+// counts are summed, while depth and fill keep the maximum of the two.
+func (s *Stats) merge(other Stats) {
+	s.Calls += other.Calls
+	s.Failures += other.Failures
+	s.Backtracks += other.Backtracks
+	s.Backjumps += other.Backjumps
+	s.Restarts += other.Restarts
+	s.NogoodHits += other.NogoodHits
+	s.Nogoods += other.Nogoods
+
+	if other.Depth > s.Depth {
+		s.Depth = other.Depth
+	}
+	if other.BestFill > s.BestFill {
+		s.BestFill = other.BestFill
+	}
+}
+
+// Restarts the counters for a build searching the given corpus.
+func (stats *buildStats) reset(corpus *Corpus) {
+	stats.calls.Store(0)
+	stats.failures.Store(0)
+	stats.backtracks.Store(0)
+	stats.backjumps.Store(0)
+	stats.restarts.Store(0)
+	stats.depth.Store(0)
+	stats.nogoodHits.Store(0)
+	stats.nogoods.Store(0)
+	stats.bestFill.Store(0)
+	stats.start.Store(time.Now().UnixNano())
+	stats.end.Store(0)
+	stats.cacheHits.Store(corpus.hits.Load())
+	stats.cacheMisses.Store(corpus.misses.Load())
+}
+
+// Returns the share of the corpus' lookups since the build began that were answered from the cache,
+// or 0 if there were none.
+func (stats *buildStats) cacheHitRate(corpus *Corpus) float64 {
+	hits := corpus.hits.Load() - stats.cacheHits.Load()
+	misses := corpus.misses.Load() - stats.cacheMisses.Load()
+	if hits+misses <= 0 {
+		return 0
+	}
+
+	return float64(hits) / float64(hits+misses)
+}
+
+func (stats *buildStats) finish() {
+	stats.end.Store(time.Now().UnixNano())
+}
+
+// Records a state with the given number of filled-in cells, keeping the maximum.
+func (stats *buildStats) fill(filled int) {
+	for {
+		best := stats.bestFill.Load()
+		if int64(filled) <= best || stats.bestFill.CompareAndSwap(best, int64(filled)) {
+			return
+		}
+	}
+}
+
+func (stats *buildStats) elapsed() time.Duration {
+	start := stats.start.Load()
+	if start == 0 {
+		return 0
+	}
+
+	end := stats.end.Load()
+	if end == 0 {
+		end = time.Now().UnixNano()
+	}
+
+	return time.Duration(end - start)
+}
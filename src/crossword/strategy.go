@@ -0,0 +1,120 @@
+package crossword
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/nitzanhen/crossword/src/structure"
+)
+
+// A cut along with the words that may be embedded in it.
+type Candidates struct {
+	Cut   Cut
+	Words []Word
+}
+
+// Decides which cut the builder tries to fill next.
+type CutOrder interface {
+	// Sorts cuts in place, into the order in which the builder should try them.
+	OrderCuts(state *SearchState, cuts []Candidates)
+}
+
+// Decides the order in which the builder tries the words of a cut.
+type WordOrder interface {
+	// Sorts the candidate words of cut in place, into the order in which the builder should try them.
+	OrderWords(state *SearchState, cut Cut, words []Word)
+}
+
+// The search state that cut and word orders decide on.
+type SearchState struct {
+	Crossword *Crossword
+	Corpus    *Corpus
+	Rand      *rand.Rand // The builder's random source; nil if it has none.
+
+	cuts  structure.Set[Cut]
+	graph *structure.Graph[Cut]
+}
+
+// Returns the number of other cuts left to fill that cross cut.
+func (state *SearchState) Degree(cut Cut) int {
+	if state.graph == nil {
+		state.graph = GetCutGraph(state.Crossword, &state.cuts)
+	}
+
+	neighbors := state.graph.Neighborhood.Get(cut)
+	if neighbors.Has(cut) {
+		return neighbors.Size() - 1
+	}
+
+	return neighbors.Size()
+}
+
+// Shuffles items with the state's random source, or with the global one if it has none.
+func (state *SearchState) shuffle(n int, swap func(i, j int)) {
+	if state.Rand != nil {
+		state.Rand.Shuffle(n, swap)
+	} else {
+		rand.Shuffle(n, swap)
+	}
+}
+
+// "Minimum remaining values": tries the cut with the fewest candidate words first,
+// as it is the most likely to fail.
+type MRV struct{}
+
+func (MRV) OrderCuts(state *SearchState, cuts []Candidates) {
+	sort.SliceStable(cuts, func(i, j int) bool {
+		return len(cuts[i].Words) < len(cuts[j].Words)
+	})
+}
+
+// Like MRV, but breaks ties in favour of the cut crossing the most other cuts left to fill,
+// as it constrains the rest of the search the most.
+type MRVDegree struct{}
+
+func (MRVDegree) OrderCuts(state *SearchState, cuts []Candidates) {
+	sort.SliceStable(cuts, func(i, j int) bool {
+		if len(cuts[i].Words) != len(cuts[j].Words) {
+			return len(cuts[i].Words) < len(cuts[j].Words)
+		}
+
+		return state.Degree(cuts[i].Cut) > state.Degree(cuts[j].Cut)
+	})
+}
+
+// Keeps the words in corpus order (shuffled, if the builder is seeded).
+type CorpusOrder struct{}
+
+func (CorpusOrder) OrderWords(state *SearchState, cut Cut, words []Word) {}
+
+// "Least constraining value": tries words made of the most frequent letters first,
+// as they leave the most options to the cuts crossing them.
+type LeastConstraining struct{}
+
+func (LeastConstraining) OrderWords(state *SearchState, cut Cut, words []Word) {
+	scores := make(map[Word]float64, len(words))
+	for _, word := range words {
+		chars := state.Corpus.charsOf(word)
+
+		score := 0.0
+		for _, letter := range chars {
+			score += state.Corpus.LetterFrequency(letter)
+		}
+		scores[word] = score / float64(len(chars))
+	}
+
+	sort.SliceStable(words, func(i, j int) bool {
+		return scores[words[i]] > scores[words[j]]
+	})
+}
+
+// Tries cuts, or words, in a random order.
+type Random struct{}
+
+func (Random) OrderCuts(state *SearchState, cuts []Candidates) {
+	state.shuffle(len(cuts), func(i, j int) { cuts[i], cuts[j] = cuts[j], cuts[i] })
+}
+
+func (Random) OrderWords(state *SearchState, cut Cut, words []Word) {
+	state.shuffle(len(words), func(i, j int) { words[i], words[j] = words[j], words[i] })
+}
@@ -0,0 +1,52 @@
+package crossword_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+)
+
+func TestMRV(t *testing.T) {
+	cuts := []crossword.Candidates{
+		{Cut: crossword.Cut{Row: 0, Col: 0, Orientation: crossword.HORIZONTAL, Len: 3}, Words: []crossword.Word{"abc", "def"}},
+		{Cut: crossword.Cut{Row: 0, Col: 0, Orientation: crossword.VERTICAL, Len: 3}, Words: []crossword.Word{"adg"}},
+	}
+
+	crossword.MRV{}.OrderCuts(&crossword.SearchState{}, cuts)
+
+	if cuts[0].Cut.Orientation != crossword.VERTICAL {
+		t.Errorf("Expected the cut with fewer words to come first, got %v", cuts)
+	}
+}
+
+func TestLeastConstraining(t *testing.T) {
+	corpus := crossword.NewCorpus([]crossword.Word{"aab", "aaa", "xyz"})
+	words := []crossword.Word{"xyz", "aab", "aaa"}
+
+	crossword.LeastConstraining{}.OrderWords(&crossword.SearchState{Corpus: corpus}, crossword.Cut{}, words)
+
+	if words[0] != "aaa" || words[1] != "aab" || words[2] != "xyz" {
+		t.Errorf("Expected words made of frequent letters to come first, got %v", words)
+	}
+}
+
+func TestStrategies(t *testing.T) {
+	corpus := crossword.NewCorpus(readWords(t))
+
+	cutOrders := []crossword.CutOrder{crossword.MRV{}, crossword.MRVDegree{}, crossword.Random{}}
+	wordOrders := []crossword.WordOrder{crossword.CorpusOrder{}, crossword.LeastConstraining{}, crossword.Random{}}
+
+	for _, cutOrder := range cutOrders {
+		for _, wordOrder := range wordOrders {
+			builder := crossword.NewBuilderWithCorpus(4, 4, corpus, false)
+			builder.SetSeed(1)
+			builder.SetCutOrder(cutOrder)
+			builder.SetWordOrder(wordOrder)
+
+			if _, err := builder.BuildContext(context.Background()); err != nil {
+				t.Errorf("Expected a build with %T and %T to succeed, got %v", cutOrder, wordOrder, err)
+			}
+		}
+	}
+}
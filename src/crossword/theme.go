@@ -0,0 +1,222 @@
+package crossword
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/nitzanhen/crossword/src/structure"
+)
+
+// A word that must appear in every crossword the builder completes.
+type ThemeWord struct {
+	Word Word
+	// The cut the word must be embedded in, or nil to let the builder place it anywhere.
+	// As with every cut, Row and Col are its top left cell whichever direction the grid is read in,
+	// so a horizontal word of a right-to-left grid starts at Col+Len-1. A length of 0 is taken to be the word's.
+	Cut *Cut
+}
+
+// Returned when the theme words cannot all be placed in the grid, before the rest of it is searched.
+// Matches ErrNoSolution under errors.Is.
+type ThemeError struct {
+	Word   Word
+	Reason string
+}
+
+func (err *ThemeError) Error() string {
+	return fmt.Sprintf("theme word %v cannot be placed: %s", err.Word, err.Reason)
+}
+
+func (err *ThemeError) Is(target error) bool {
+	return target == ErrNoSolution
+}
+
+// Makes every crossword the builder completes contain the given theme words, which need not be in its corpus.
+// Pinned words are embedded in their cut before the search starts; the others are placed first, longest first,
+// and the rest of the grid is filled around them.
+//
+// Builds fail with a *ThemeError if the theme words cannot all be placed, without searching the rest of the grid.
+func (builder *Builder) SetThemeWords(words []ThemeWord) {
+	builder.themeWords = make([]ThemeWord, len(words))
+	copy(builder.themeWords, words)
+
+	builder.freeThemes = Map(
+		Filter(words, func(theme ThemeWord) bool { return theme.Cut == nil }),
+		func(theme ThemeWord) Word { return theme.Word },
+	)
+	sort.SliceStable(builder.freeThemes, func(i, j int) bool {
+		return len(Chars(string(builder.freeThemes[i]))) > len(Chars(string(builder.freeThemes[j])))
+	})
+}
+
+// Returns the first of the builder's free theme words that is not yet embedded in cw, and whether there is one.
+func (builder *Builder) pendingThemeWord(cw *Crossword) (Word, bool) {
+	for _, word := range builder.freeThemes {
+		if !cw.IsWordEmbedded(word) {
+			return word, true
+		}
+	}
+
+	return "", false
+}
+
+// Embeds the pinned theme words in cw, and checks that the free ones can be placed alongside them.
+// Returns a *ThemeError otherwise, or the context's error if ctx is done during the check.
+func (builder *Builder) placeThemeWords(ctx context.Context, cw *Crossword) error {
+	listed := structure.NewSet[Word](len(builder.themeWords))
+	pinned := []Word{}
+
+	for _, theme := range builder.themeWords {
+		word := theme.Word
+		if listed.Has(word) {
+			return &ThemeError{word, "it is listed more than once"}
+		}
+		listed.Add(word)
+
+		if theme.Cut == nil {
+			continue
+		}
+
+		cut := Cut{theme.Cut.Row, theme.Cut.Col, theme.Cut.Orientation, len(Chars(string(word)))}
+		if theme.Cut.Len != 0 && theme.Cut.Len != cut.Len {
+			return &ThemeError{word, fmt.Sprintf("its cut has length %d", theme.Cut.Len)}
+		}
+
+		pinned = append(pinned, word)
+
+		isEmbedded := func(cutword CutWithWord) bool { return cutword == CutWithWord{cut, word} }
+		if FirstIndex(cw.Embeddings, isEmbedded) != -1 {
+			// Already in the grid
+			continue
+		}
+
+		endRow, endCol := Move(cut.Row, cut.Col, cut.Orientation, cut.Len-1)
+		if !cw.IsValid(cut.Row, cut.Col) || !cw.IsValid(endRow, endCol) {
+			return &ThemeError{word, fmt.Sprintf("%v is out of bounds", cut.String())}
+		}
+		if !builder.allowsPlacement(cw, cut) {
+			return &ThemeError{word, fmt.Sprintf("%v breaks the template or symmetry", cut.String())}
+		}
+		if err := cw.Embed(cut, word); err != nil {
+			return &ThemeError{word, err.Error()}
+		}
+		if err := checkEmbeddings(cw); err != nil {
+			return &ThemeError{word, err.Error()}
+		}
+		builder.mirrorStops(cw)
+	}
+
+	free := Filter(builder.freeThemes, func(word Word) bool { return !cw.IsWordEmbedded(word) })
+	if len(free) == 0 {
+		return nil
+	}
+
+	deepest := 0
+	if builder.fitThemeWords(ctx, cw, builder.openCuts(cw), free, 0, &deepest) {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	placed := append(pinned, free[:deepest]...)
+	if len(placed) == 0 {
+		return &ThemeError{free[0], "it fits in no cut of the grid"}
+	}
+
+	return &ThemeError{free[deepest], fmt.Sprintf("it does not fit alongside the theme words %v", placed)}
+}
+
+// Checks whether the given words can all be embedded in cw's cuts together, regardless of the corpus.
+// Records the index of the furthest word that could not be placed in deepest. Gives up once ctx is done.
+func (builder *Builder) fitThemeWords(
+	ctx context.Context,
+	cw *Crossword,
+	cuts structure.Set[Cut],
+	words []Word,
+	index int,
+	deepest *int,
+) bool {
+	if index == len(words) {
+		return true
+	}
+	if ctx.Err() != nil {
+		return false
+	}
+
+	*deepest = Max(*deepest, index)
+
+	for _, next := range builder.themeEmbeddings(cw, cuts, words[index]) {
+		if builder.fitThemeWords(ctx, &next.cw, next.cuts, words, index+1, deepest) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// A state following the embedding of a theme word.
+type themeEmbedding struct {
+	cw   Crossword
+	cuts structure.Set[Cut]
+}
+
+// Returns the states following each possible embedding of word in one of the given cuts, in order of the cuts.
+func (builder *Builder) themeEmbeddings(cw *Crossword, cuts structure.Set[Cut], word Word) []themeEmbedding {
+	sorted := cuts.ToSlice()
+	sort.Slice(sorted, func(i, j int) bool { return compareCuts(sorted[i], sorted[j]) < 0 })
+
+	embeddings := []themeEmbedding{}
+	for _, cut := range sorted {
+		for _, p := range builder.getPlacements(cw, cut, []Word{word}) {
+			next := cw.Copy()
+
+			subcut := cw.Subcut(cut, p.offset, p.offset+len(p.chars))
+			next.Embed(subcut, word)
+			builder.mirrorStops(&next)
+
+			nextCuts := structure.SetFromSlice(builder.subcutsOf(&next, sorted))
+			nextCuts.Delete(subcut)
+
+			embeddings = append(embeddings, themeEmbedding{next, nextCuts})
+		}
+	}
+
+	return embeddings
+}
+
+// Searches for a crossword in which the given theme word, which is not yet embedded, is placed in one of the cuts.
+// Theme words may be placed anywhere, so a failure is blamed on every embedding made so far.
+func (builder *Builder) searchThemeWord(
+	ctx context.Context,
+	cw *Crossword,
+	cuts structure.Set[Cut],
+	word Word,
+) (*Crossword, structure.Set[int], error) {
+	conflict := structure.SetFromSlice(IndexArray(len(cw.Embeddings)))
+
+	embeddings := builder.themeEmbeddings(cw, cuts, word)
+	if builder.rng != nil {
+		builder.rng.Shuffle(len(embeddings), func(i, j int) {
+			embeddings[i], embeddings[j] = embeddings[j], embeddings[i]
+		})
+	}
+
+	for _, next := range embeddings {
+		builder.stats.fill(next.cw.CountFilled())
+
+		result, _, err := builder.build(ctx, &next.cw, next.cuts)
+		if err != nil {
+			return nil, conflict, err
+		}
+		if result != nil {
+			return result, conflict, nil
+		}
+
+		builder.stats.backtracks.Add(1)
+	}
+
+	builder.stats.failures.Add(1)
+	return nil, conflict, nil
+}
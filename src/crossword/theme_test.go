@@ -0,0 +1,89 @@
+package crossword_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+)
+
+func TestThemeWords(t *testing.T) {
+	// bd is missing from the corpus, so only the theme completes either square
+	builder := crossword.NewBuilder(2, 2, []crossword.Word{"ab", "cd", "ac"}, false)
+	if _, err := builder.BuildContext(context.Background()); !errors.Is(err, crossword.ErrNoSolution) {
+		t.Fatalf("Expected ErrNoSolution without the theme word, got %v", err)
+	}
+
+	builder.SetThemeWords([]crossword.ThemeWord{{Word: "bd"}})
+	cw, err := builder.BuildContext(context.Background())
+	if err != nil {
+		t.Fatalf("Expected builder.BuildContext() to succeed, got %v", err)
+	}
+	if !cw.IsWordEmbedded("bd") {
+		t.Errorf("Expected the theme word to be embedded, got:\n%s", cw.PrintData())
+	}
+	if count, _ := builder.Count(context.Background()); count != 2 {
+		t.Errorf("Expected 2 crosswords with the theme word, got %d", count)
+	}
+
+	pinned := crossword.Cut{Row: 1, Col: 0, Orientation: crossword.HORIZONTAL}
+	builder.SetThemeWords([]crossword.ThemeWord{{Word: "bd", Cut: &pinned}})
+	cw, err = builder.BuildContext(context.Background())
+	if err != nil {
+		t.Fatalf("Expected builder.BuildContext() to succeed with a pinned word, got %v", err)
+	}
+	if cw.Data[1][0] != "b" || cw.Data[1][1] != "d" {
+		t.Errorf("Expected the theme word in its cut, got:\n%s", cw.PrintData())
+	}
+	if count, _ := builder.Count(context.Background()); count != 1 {
+		t.Errorf("Expected 1 crossword with the pinned word, got %d", count)
+	}
+
+	// Right to left, the same cut is read from its rightmost cell
+	builder.SetDirection(crossword.RIGHT_TO_LEFT)
+	cw, err = builder.BuildContext(context.Background())
+	if err != nil {
+		t.Fatalf("Expected builder.BuildContext() to succeed right to left, got %v", err)
+	}
+	if !reflect.DeepEqual(cw.Data, [][]string{{"c", "a"}, {"d", "b"}}) || !cw.IsCutEmbedded(crossword.Cut{Row: 1, Col: 0, Orientation: crossword.HORIZONTAL, Len: 2}) {
+		t.Errorf("Expected the theme word read right to left in its cut, got:\n%s", cw.PrintData())
+	}
+}
+
+func TestThemeErrors(t *testing.T) {
+	row := crossword.Cut{Row: 0, Col: 0, Orientation: crossword.HORIZONTAL}
+	col := crossword.Cut{Row: 0, Col: 0, Orientation: crossword.VERTICAL}
+
+	tests := []struct {
+		name  string
+		words []crossword.ThemeWord
+		word  crossword.Word
+	}{
+		{"too long", []crossword.ThemeWord{{Word: "abc"}}, "abc"},
+		{"listed twice", []crossword.ThemeWord{{Word: "ab"}, {Word: "ab"}}, "ab"},
+		{"crossing pins", []crossword.ThemeWord{{Word: "ab", Cut: &row}, {Word: "cd", Cut: &col}}, "cd"},
+		{"no room left", []crossword.ThemeWord{{Word: "ab"}, {Word: "cd"}, {Word: "ef"}}, "ef"},
+	}
+
+	for _, test := range tests {
+		builder := crossword.NewBuilder(2, 2, squareWords, false)
+		builder.SetThemeWords(test.words)
+
+		for _, build := range []func() (*crossword.Crossword, error){
+			func() (*crossword.Crossword, error) { return builder.BuildContext(context.Background()) },
+			func() (*crossword.Crossword, error) { return builder.BuildParallel(context.Background(), 2) },
+		} {
+			_, err := build()
+
+			var themeErr *crossword.ThemeError
+			if !errors.As(err, &themeErr) || themeErr.Word != test.word {
+				t.Errorf("%s: expected a ThemeError for %v, got %v", test.name, test.word, err)
+			}
+			if !errors.Is(err, crossword.ErrNoSolution) {
+				t.Errorf("%s: expected the error to match ErrNoSolution", test.name)
+			}
+		}
+	}
+}
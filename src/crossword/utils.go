@@ -71,3 +71,11 @@ func IndexArray(size int) []int {
 
 	return arr
 }
+
+func Max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
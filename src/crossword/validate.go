@@ -0,0 +1,217 @@
+package crossword
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nitzanhen/crossword/src/structure"
+)
+
+type ViolationKind int
+
+const (
+	UNKNOWN_WORD       ViolationKind = iota // A run of letters that is not in the corpus
+	DUPLICATE_WORD     ViolationKind = iota // A word that appears more than once
+	EMBEDDING_MISMATCH ViolationKind = iota // An embedding that disagrees with the data
+	INVALID_CELL       ViolationKind = iota // A cell that holds neither a letter of the corpus, Empty nor Stop
+	MISPLACED_STOP     ViolationKind = iota // A stop that delimits no cell, and was not placed by the layout
+)
+
+func (kind ViolationKind) String() string {
+	switch kind {
+	case UNKNOWN_WORD:
+		return "unknown word"
+	case DUPLICATE_WORD:
+		return "duplicate word"
+	case EMBEDDING_MISMATCH:
+		return "embedding mismatch"
+	case INVALID_CELL:
+		return "invalid cell"
+	case MISPLACED_STOP:
+		return "misplaced stop"
+	}
+
+	panic(fmt.Sprintf("Invalid ViolationKind %d", int(kind)))
+}
+
+// A way in which a crossword breaks the rules of the grid.
+type Violation struct {
+	Kind ViolationKind `json:"kind"`
+	// The run, embedding or cell at fault; a cell is a cut of length 1.
+	Cut Cut `json:"cut"`
+	// The word involved, if any.
+	Word    Word   `json:"word,omitempty"`
+	Message string `json:"message"`
+}
+
+func (violation Violation) Error() string {
+	return fmt.Sprintf("%v: %s", violation.Kind, violation.Message)
+}
+
+// Checks and returns whether value is a letter: a value other than Empty and Stop that a word of the corpus
+// is split into (see Chars), such as a combining mark or an apostrophe. Without a corpus, any single character is.
+func (cw *Crossword) isLetterValue(value string, corpus *Corpus) bool {
+	if value == cw.Empty || value == cw.Stop {
+		return false
+	}
+	if corpus != nil {
+		return corpus.hasLetter(value)
+	}
+
+	return utf8.RuneCountInString(value) == 1
+}
+
+// Checks the crossword against the rules of the grid, and returns the violations found, in reading order of each kind.
+// An empty result means the crossword is valid.
+//
+// Every complete run of letters (bounded by stops or the grid's edges) longer than a single cell must be a word
+// of the corpus, and no word may appear twice; a nil corpus skips the former check.
+// Embeddings must lie within the grid, match its data and be delimited by stops.
+// Every cell must hold a letter, Empty or Stop, and every stop must delimit some cell that is not a stop.
+//
+// Stops placed by a template or grid are not bound to delimit anything; to leave them out of the latter check,
+// validate with the builder that built the crossword (see Builder.Validate), or pass them to ValidateLayout.
+func (cw *Crossword) Validate(corpus *Corpus) []Violation {
+	return cw.ValidateLayout(corpus, nil)
+}
+
+// Checks the crossword as Validate does, but doesn't report the stops of layout (where layout[row][col] is true)
+// as misplaced. A nil layout places no stops.
+func (cw *Crossword) ValidateLayout(corpus *Corpus, layout [][]bool) []Violation {
+	violations := []Violation{}
+	violations = append(violations, cw.cellViolations(corpus, layout)...)
+	violations = append(violations, cw.runViolations(corpus)...)
+	violations = append(violations, cw.embeddingViolations()...)
+
+	// A word embedded twice is usually also found twice among the runs
+	type key struct {
+		kind ViolationKind
+		cut  Cut
+		word Word
+	}
+	reported := structure.NewSet[key](len(violations))
+
+	return Filter(violations, func(violation Violation) bool {
+		k := key{violation.Kind, violation.Cut, violation.Word}
+		if reported.Has(k) {
+			return false
+		}
+
+		reported.Add(k)
+		return true
+	})
+}
+
+// Returns the cells that hold neither a letter, Empty nor Stop,
+// and the stops surrounded by stops and edges that are not stops of the layout.
+func (cw *Crossword) cellViolations(corpus *Corpus, layout [][]bool) []Violation {
+	invalid, misplaced := []Violation{}, []Violation{}
+
+	for i, row := range cw.Data {
+		for j, value := range row {
+			cell := Cut{i, j, HORIZONTAL, 1}
+
+			switch {
+			case value == cw.Stop:
+				if i < len(layout) && j < len(layout[i]) && layout[i][j] {
+					continue
+				}
+
+				delimits := false
+				for _, step := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+					ni, nj := i+step[0], j+step[1]
+					delimits = delimits || (cw.IsValid(ni, nj) && cw.Data[ni][nj] != cw.Stop)
+				}
+
+				if !delimits {
+					message := fmt.Sprintf("the stop at (%d, %d) is surrounded by stops", i, j)
+					misplaced = append(misplaced, Violation{MISPLACED_STOP, cell, "", message})
+				}
+			case value != cw.Empty && !cw.isLetterValue(value, corpus):
+				message := fmt.Sprintf("cell (%d, %d) holds %q", i, j, value)
+				invalid = append(invalid, Violation{INVALID_CELL, cell, "", message})
+			}
+		}
+	}
+
+	return append(invalid, misplaced...)
+}
+
+// Returns the complete runs of letters that are not in the corpus, and those that repeat an earlier run.
+func (cw *Crossword) runViolations(corpus *Corpus) []Violation {
+	unknown, duplicates := []Violation{}, []Violation{}
+	seen := structure.NewSet[Word](0)
+
+	for _, run := range cw.GetCuts() {
+		data := cw.GetCutData(run)
+		if FirstIndex(data, func(value string) bool { return !cw.isLetterValue(value, corpus) }) != -1 {
+			// Incomplete, or already reported as invalid
+			continue
+		}
+
+		word := Word(strings.Join(data, ""))
+		if corpus != nil && !corpus.Has(word) {
+			message := fmt.Sprintf("%v in %v is not in the corpus", word, run.String())
+			unknown = append(unknown, Violation{UNKNOWN_WORD, run, word, message})
+		}
+
+		if seen.Has(word) {
+			message := fmt.Sprintf("%v appears again in %v", word, run.String())
+			duplicates = append(duplicates, Violation{DUPLICATE_WORD, run, word, message})
+		}
+		seen.Add(word)
+	}
+
+	return append(unknown, duplicates...)
+}
+
+// Checks a crossword built by the builder as Validate does, against the builder's corpus.
+// The stops of the builder's layout (those of its grid and template, and their mirrors under its symmetry)
+// are not reported as misplaced.
+func (builder *Builder) Validate(cw *Crossword) []Violation {
+	return cw.ValidateLayout(builder.corpus, builder.layoutStops())
+}
+
+// Returns the embeddings that lie outside the grid, do not match its data, are not delimited by stops,
+// or repeat the word of an earlier embedding.
+func (cw *Crossword) embeddingViolations() []Violation {
+	violations := []Violation{}
+	words := structure.NewSet[Word](len(cw.Embeddings))
+
+	for _, cutword := range cw.Embeddings {
+		cut, word := cutword.Cut, cutword.Word
+		mismatch := func(format string, args ...any) {
+			message := fmt.Sprintf("word %v in %v ", word, cut.String()) + fmt.Sprintf(format, args...)
+			violations = append(violations, Violation{EMBEDDING_MISMATCH, cut, word, message})
+		}
+
+		if words.Has(word) {
+			message := fmt.Sprintf("word %v is embedded more than once", word)
+			violations = append(violations, Violation{DUPLICATE_WORD, cut, word, message})
+		}
+		words.Add(word)
+
+		endRow, endCol := Move(cut.Row, cut.Col, cut.Orientation, cut.Len-1)
+		if cut.Len < 1 || !cw.IsValid(cut.Row, cut.Col) || !cw.IsValid(endRow, endCol) {
+			mismatch("is out of bounds")
+			continue
+		}
+
+		if data := cw.GetCutData(cut); len(Chars(string(word))) != cut.Len || strings.Join(data, "") != string(word) {
+			mismatch("does not match the data %v", strings.Join(data, ""))
+			continue
+		}
+
+		preRow, preCol := Move(cut.Row, cut.Col, cut.Orientation, -1)
+		postRow, postCol := Move(cut.Row, cut.Col, cut.Orientation, cut.Len)
+		for _, cell := range [][2]int{{preRow, preCol}, {postRow, postCol}} {
+			if cw.IsValid(cell[0], cell[1]) && cw.Data[cell[0]][cell[1]] != cw.Stop {
+				mismatch("is not delimited by stops")
+				break
+			}
+		}
+	}
+
+	return violations
+}
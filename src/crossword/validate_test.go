@@ -0,0 +1,145 @@
+package crossword_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+)
+
+func TestValidate(t *testing.T) {
+	corpus := crossword.NewCorpus(squareWords)
+
+	cw := crossword.NewCrossword(2, 2)
+	cw.Embed(crossword.Cut{Row: 0, Col: 0, Orientation: crossword.HORIZONTAL, Len: 2}, "ab")
+	cw.Embed(crossword.Cut{Row: 1, Col: 0, Orientation: crossword.HORIZONTAL, Len: 2}, "cd")
+	if violations := cw.Validate(corpus); len(violations) != 0 {
+		t.Errorf("Expected a valid crossword, got %v", violations)
+	}
+
+	tests := []struct {
+		name string
+		edit func(cw *crossword.Crossword)
+		kind crossword.ViolationKind
+	}{
+		{"unknown word", func(cw *crossword.Crossword) { cw.Data[1][1] = "a" }, crossword.UNKNOWN_WORD},
+		{"invalid cell", func(cw *crossword.Crossword) { cw.Data[1][1] = "?" }, crossword.INVALID_CELL},
+		{"duplicate word", func(cw *crossword.Crossword) {
+			cw.Data[1][0], cw.Data[1][1] = "a", "b"
+		}, crossword.DUPLICATE_WORD},
+		{"embedding mismatch", func(cw *crossword.Crossword) {
+			cw.Embeddings[0].Word = "ba"
+		}, crossword.EMBEDDING_MISMATCH},
+		{"misplaced stop", func(cw *crossword.Crossword) {
+			cw.Data[0][0], cw.Data[0][1], cw.Data[1][0], cw.Data[1][1] = cw.Stop, cw.Stop, cw.Stop, cw.Stop
+			cw.Embeddings = nil
+		}, crossword.MISPLACED_STOP},
+	}
+
+	for _, test := range tests {
+		edited := cw.Copy()
+		test.edit(&edited)
+
+		violations := edited.Validate(corpus)
+		found := false
+		for _, violation := range violations {
+			found = found || violation.Kind == test.kind
+		}
+		if !found {
+			t.Errorf("%s: expected a %v violation, got %v", test.name, test.kind, violations)
+		}
+	}
+}
+
+func TestValidateLetters(t *testing.T) {
+	// A decomposed é is split into e and a combining mark, and o’clock keeps its apostrophe
+	words := []crossword.Word{crossword.Word(norm.NFD.String("éa")), "o’c"}
+	corpus := crossword.NewCorpus(words)
+
+	cw := crossword.NewCrossword(3, 2)
+	for _, cutword := range []crossword.CutWithWord{
+		{Cut: crossword.Cut{Row: 0, Col: 0, Orientation: crossword.HORIZONTAL, Len: 3}, Word: words[0]},
+		{Cut: crossword.Cut{Row: 1, Col: 0, Orientation: crossword.HORIZONTAL, Len: 3}, Word: words[1]},
+	} {
+		if err := cw.Embed(cutword.Cut, cutword.Word); err != nil {
+			t.Fatalf("Expected cw.Embed() to succeed, got %v", err)
+		}
+	}
+
+	for _, violation := range cw.Validate(corpus) {
+		if violation.Kind == crossword.INVALID_CELL {
+			t.Errorf("Expected the letters of corpus words to be valid cells, got %v", violation)
+		}
+	}
+	for _, violation := range cw.Validate(nil) {
+		if violation.Kind == crossword.INVALID_CELL {
+			t.Errorf("Expected any single character to be a valid cell without a corpus, got %v", violation)
+		}
+	}
+
+	cw.Data[1][2] = "?"
+	if violations := cw.Validate(corpus); len(violations) == 0 || violations[0].Kind != crossword.INVALID_CELL {
+		t.Errorf("Expected a character outside the corpus to be an invalid cell, got %v", violations)
+	}
+}
+
+func TestValidateLayout(t *testing.T) {
+	// The template's top left corner is a block of stops, none of which delimits a cell
+	template := [][]bool{
+		{true, true, false},
+		{true, true, false},
+		{false, false, false},
+	}
+	words := []crossword.Word{"abc", "cfi", "gh", "ghi", "ab"}
+
+	builder := crossword.NewBuilder(3, 3, words, false)
+	if err := builder.SetTemplate(template); err != nil {
+		t.Fatalf("Expected builder.SetTemplate() to succeed, got %v", err)
+	}
+
+	cw := crossword.NewCrossword(3, 3)
+	cw.Data = [][]string{
+		{"1", "1", "c"},
+		{"1", "1", "f"},
+		{"g", "h", "i"},
+	}
+
+	if violations := builder.Validate(&cw); len(violations) != 0 {
+		t.Errorf("Expected the template's stops to be valid, got %v", violations)
+	}
+
+	misplaced := 0
+	for _, violation := range cw.Validate(nil) {
+		if violation.Kind == crossword.MISPLACED_STOP {
+			misplaced++
+		}
+	}
+	if misplaced != 1 {
+		t.Errorf("Expected the stop surrounded by stops to be misplaced outside the template, got %d", misplaced)
+	}
+}
+
+func TestValidateBuilt(t *testing.T) {
+	words := readWords(t)
+	corpus := crossword.NewCorpus(words)
+
+	for seed := int64(0); seed < 4; seed++ {
+		builder := crossword.NewBuilderWithCorpus(5, 5, corpus, false)
+		builder.SetSeed(seed)
+		builder.SetRestarts(crossword.LubyRestarts(100))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		cw, err := builder.BuildContext(ctx)
+		cancel()
+		if err != nil {
+			t.Fatalf("Expected builder.BuildContext() to succeed, got %v", err)
+		}
+
+		if violations := cw.Validate(corpus); len(violations) != 0 {
+			t.Errorf("Expected built crosswords to be valid, got %v in:\n%s", violations, cw.PrintData())
+		}
+	}
+}
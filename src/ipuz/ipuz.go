@@ -0,0 +1,271 @@
+// Reads and writes crosswords in the ipuz JSON format (http://ipuz.org).
+package ipuz
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+)
+
+const (
+	VERSION = "http://ipuz.org/v2"
+	KIND    = "http://ipuz.org/crossword#1"
+
+	BLOCK = "#" // Marks block cells, unless a file sets its own
+	EMPTY = 0   // Marks unnumbered cells in the puzzle, unless a file sets its own
+
+	ACROSS = "Across"
+	DOWN   = "Down"
+
+	// The extension field the direction of a crossword is written in, "ltr" or "rtl" (see crossword.Direction)
+	DIRECTION_FIELD = "github.com/nitzanhen/crossword:direction"
+)
+
+// The JSON form of a puzzle. Only the fields this package reads and writes are listed.
+type puzzleJSON struct {
+	Version    string   `json:"version"`
+	Kind       []string `json:"kind"`
+	Title      string   `json:"title,omitempty"`
+	Author     string   `json:"author,omitempty"`
+	Copyright  string   `json:"copyright,omitempty"`
+	Notes      string   `json:"notes,omitempty"`
+	Dimensions struct {
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	} `json:"dimensions"`
+	Block    string                       `json:"block,omitempty"`
+	Empty    json.RawMessage              `json:"empty,omitempty"`
+	Puzzle   [][]any                      `json:"puzzle"`
+	Solution [][]any                      `json:"solution"`
+	Clues    map[string][]json.RawMessage `json:"clues"`
+
+	Direction string `json:"github.com/nitzanhen/crossword:direction,omitempty"` // See DIRECTION_FIELD
+}
+
+// The direction clues of an orientation are listed under.
+func direction(o crossword.Orientation) string {
+	if o == crossword.VERTICAL {
+		return DOWN
+	}
+
+	return ACROSS
+}
+
+// Writes the puzzle in the ipuz format, numbered by crossword.Number. Letters are written in upper case,
+// and stops and empty cells as blocks (see crossword.Completed).
+// Rows are written as displayed, whatever the crossword's direction, which is written in the DIRECTION_FIELD extension.
+func Write(w io.Writer, puzzle *crossword.Puzzle) error {
+	completed := puzzle.Crossword.Completed()
+	cw := &completed
+
+	var out puzzleJSON
+	out.Version = VERSION
+	out.Kind = []string{KIND}
+	out.Title, out.Author, out.Copyright, out.Notes = puzzle.Title, puzzle.Author, puzzle.Copyright, puzzle.Notes
+	out.Dimensions.Width, out.Dimensions.Height = cw.Width, cw.Height
+	out.Block = BLOCK
+	out.Empty = json.RawMessage(strconv.Itoa(EMPTY))
+	out.Direction = cw.Direction.String()
+
+	numbering := cw.Number()
+	out.Puzzle = crossword.MakeMatrix(cw.Height, cw.Width, func(i, j int) any {
+		if cw.Data[i][j] == cw.Stop {
+			return BLOCK
+		}
+		return numbering.Numbers[i][j]
+	})
+	out.Solution = crossword.MakeMatrix(cw.Height, cw.Width, func(i, j int) any {
+		if cw.Data[i][j] == cw.Stop {
+			return BLOCK
+		}
+		return strings.ToUpper(cw.Data[i][j])
+	})
+
+	out.Clues = map[string][]json.RawMessage{ACROSS: {}, DOWN: {}}
+	for _, entry := range numbering.Entries() {
+		raw, err := json.Marshal([]any{entry.Number, puzzle.ClueOf(entry.Cut)})
+		if err != nil {
+			return err
+		}
+
+		dir := direction(entry.Cut.Orientation)
+		out.Clues[dir] = append(out.Clues[dir], raw)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(out)
+}
+
+// Returns the value of a cell, which may be given as is or as an object holding it under key.
+func cellValue(cell any, key string) any {
+	if object, ok := cell.(map[string]any); ok {
+		return object[key]
+	}
+
+	return cell
+}
+
+// Returns the value as a string, formatting numbers as integers. Null values are returned as "".
+func stringValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	return ""
+}
+
+// Parses a clue, which is either a [number, text] pair, an object with number and clue fields, or a string.
+func parseClue(raw json.RawMessage) (number string, text string, err error) {
+	var pair []any
+	if err := json.Unmarshal(raw, &pair); err == nil {
+		if len(pair) < 2 {
+			return "", "", fmt.Errorf("invalid clue %s", raw)
+		}
+		return stringValue(pair[0]), stringValue(pair[1]), nil
+	}
+
+	var object struct {
+		Number any    `json:"number"`
+		Clue   string `json:"clue"`
+	}
+	if err := json.Unmarshal(raw, &object); err == nil {
+		return stringValue(object.Number), object.Clue, nil
+	}
+
+	var str string
+	if err := json.Unmarshal(raw, &str); err != nil {
+		return "", "", fmt.Errorf("invalid clue %s", raw)
+	}
+
+	return "", str, nil
+}
+
+// Reads a crossword puzzle in the ipuz format. Letters are read in lower case; blocks and omitted cells as stops.
+// The crossword is read in the direction of the DIRECTION_FIELD extension; files without it are read right to left
+// if only that numbers their cells as they are. Every entry is embedded in the crossword, and clued in Clues by its number.
+//
+// Returns an error if the file is not an ipuz crossword, if its grids do not match its dimensions,
+// if its direction is unknown, or if a clue's number does not start an entry of its direction.
+func Read(r io.Reader) (*crossword.Puzzle, error) {
+	var in puzzleJSON
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return nil, fmt.Errorf("ipuz: %w", err)
+	}
+
+	isCrossword := false
+	for _, kind := range in.Kind {
+		isCrossword = isCrossword || strings.HasPrefix(kind, "http://ipuz.org/crossword")
+	}
+	if !isCrossword {
+		return nil, fmt.Errorf("ipuz: not a crossword, kind is %v", in.Kind)
+	}
+
+	width, height := in.Dimensions.Width, in.Dimensions.Height
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("ipuz: invalid dimensions %dx%d", width, height)
+	}
+	for name, grid := range map[string][][]any{"puzzle": in.Puzzle, "solution": in.Solution} {
+		if len(grid) != height {
+			return nil, fmt.Errorf("ipuz: %s has %d rows, expected %d", name, len(grid), height)
+		}
+		for i, row := range grid {
+			if len(row) != width {
+				return nil, fmt.Errorf("ipuz: row %d of %s has %d cells, expected %d", i, name, len(row), width)
+			}
+		}
+	}
+
+	block := in.Block
+	if block == "" {
+		block = BLOCK
+	}
+
+	cw := crossword.NewCrossword(width, height)
+	for row := 0; row < height; row++ {
+		for col := 0; col < width; col++ {
+			value := cellValue(in.Solution[row][col], "value")
+			cell := cellValue(in.Puzzle[row][col], "cell")
+			if value == nil || stringValue(value) == block || cell == nil || stringValue(cell) == block {
+				cw.Data[row][col] = cw.Stop
+				continue
+			}
+
+			letter := strings.ToLower(stringValue(value))
+			if letter == "" || letter == cw.Empty || letter == cw.Stop {
+				return nil, fmt.Errorf("ipuz: invalid solution %q at (%d, %d)", letter, row, col)
+			}
+			cw.Data[row][col] = letter
+		}
+	}
+
+	puzzle := &crossword.Puzzle{
+		Crossword: &cw,
+		Title:     in.Title,
+		Author:    in.Author,
+		Copyright: in.Copyright,
+		Notes:     in.Notes,
+	}
+
+	switch in.Direction {
+	case crossword.LEFT_TO_RIGHT.String():
+	case crossword.RIGHT_TO_LEFT.String():
+		cw.Direction = crossword.RIGHT_TO_LEFT
+	case "":
+		numbers := crossword.MakeMatrix(height, width, func(i, j int) int {
+			number, _ := strconv.Atoi(stringValue(cellValue(in.Puzzle[i][j], "cell")))
+			return number
+		})
+
+		isNumbered := func(direction crossword.Direction) bool {
+			cw.Direction = direction
+			return reflect.DeepEqual(cw.Number().Numbers, numbers)
+		}
+		if isNumbered(crossword.LEFT_TO_RIGHT) || !isNumbered(crossword.RIGHT_TO_LEFT) {
+			cw.Direction = crossword.LEFT_TO_RIGHT
+		}
+	default:
+		return nil, fmt.Errorf("ipuz: unknown direction %q", in.Direction)
+	}
+
+	cw.EmbedEntries()
+
+	starts := map[string]crossword.Cut{}
+	for _, entry := range cw.Number().Entries() {
+		starts[direction(entry.Cut.Orientation)+strconv.Itoa(entry.Number)] = entry.Cut
+	}
+
+	for _, dir := range []string{ACROSS, DOWN} {
+		clues := []json.RawMessage{}
+		for key, listed := range in.Clues {
+			// Directions may carry a label, as in "Across:Horizontal"
+			if name, _, _ := strings.Cut(key, ":"); name == dir {
+				clues = append(clues, listed...)
+			}
+		}
+
+		for _, raw := range clues {
+			number, text, err := parseClue(raw)
+			if err != nil {
+				return nil, fmt.Errorf("ipuz: %w", err)
+			}
+
+			cut, ok := starts[dir+number]
+			if !ok {
+				return nil, fmt.Errorf("ipuz: clue %s %s does not start an entry", number, dir)
+			}
+			puzzle.Clues = append(puzzle.Clues, crossword.Clue{Cut: cut, Text: text})
+		}
+	}
+
+	return puzzle, nil
+}
@@ -0,0 +1,177 @@
+package ipuz_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+	"github.com/nitzanhen/crossword/src/ipuz"
+)
+
+// Returns the clues of the puzzle by the words of their entries.
+func cluesByWord(puzzle *crossword.Puzzle) map[crossword.Word]string {
+	clues := map[crossword.Word]string{}
+	for _, clue := range puzzle.Clues {
+		clues[crossword.Word(strings.Join(puzzle.Crossword.GetCutData(clue.Cut), ""))] = clue.Text
+	}
+
+	return clues
+}
+
+func TestWrite(t *testing.T) {
+	// The last column holds a single open cell, which is not an entry
+	cw := crossword.NewCrossword(4, 3)
+	cw.Embed(crossword.Cut{Row: 0, Col: 0, Orientation: crossword.HORIZONTAL, Len: 3}, "dog")
+	cw.Embed(crossword.Cut{Row: 1, Col: 2, Orientation: crossword.HORIZONTAL, Len: 2}, "ox")
+	cw.Embed(crossword.Cut{Row: 2, Col: 0, Orientation: crossword.HORIZONTAL, Len: 3}, "bee")
+	cw.Embed(crossword.Cut{Row: 0, Col: 0, Orientation: crossword.VERTICAL, Len: 3}, "dab")
+	cw.Embed(crossword.Cut{Row: 0, Col: 2, Orientation: crossword.VERTICAL, Len: 3}, "goe")
+	cw.Data[1][1] = cw.Stop
+
+	// Only some of the entries are clued
+	puzzle := crossword.NewPuzzle(&cw, map[crossword.Word]string{"dog": "Canine", "ox": "Yoked beast", "dab": "Light touch"})
+	puzzle.Title = "Partly clued"
+
+	var buf bytes.Buffer
+	if err := ipuz.Write(&buf, puzzle); err != nil {
+		t.Fatalf("Expected ipuz.Write() to succeed, got %v", err)
+	}
+
+	var written struct {
+		Puzzle [][]any             `json:"puzzle"`
+		Clues  map[string][][2]any `json:"clues"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &written); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if expected := "[[1 0 2 #] [0 # 3 0] [4 0 0 #]]"; fmt.Sprint(written.Puzzle) != expected {
+		t.Errorf("Expected the puzzle %s, got %v", expected, written.Puzzle)
+	}
+	if expected := "map[Across:[[1 Canine] [3 Yoked beast] [4 ]] Down:[[1 Light touch] [2 ]]]"; fmt.Sprint(written.Clues) != expected {
+		t.Errorf("Expected the clues %s, got %v", expected, written.Clues)
+	}
+
+	read, err := ipuz.Read(&buf)
+	if err != nil {
+		t.Fatalf("Expected ipuz.Read() to succeed, got %v", err)
+	}
+	if !reflect.DeepEqual(read.Crossword.Data, cw.Data) || read.Title != puzzle.Title {
+		t.Errorf("Expected the puzzle to round trip, got:\n%s", read.Crossword.PrintData())
+	}
+	for _, cutword := range cw.Embeddings {
+		if cutword.Cut.Len > 1 && !read.Crossword.IsCutEmbedded(cutword.Cut) {
+			t.Errorf("Expected %v to be embedded at %v", cutword.Word, cutword.Cut.String())
+		}
+	}
+	expected := map[crossword.Word]string{"dog": "Canine", "ox": "Yoked beast", "bee": "", "dab": "Light touch", "goe": ""}
+	if got := cluesByWord(read); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected clues %v, got %v", expected, got)
+	}
+
+	// Builders leave the cells no word covers empty, like the last one here
+	isolated := crossword.NewCrossword(3, 2)
+	isolated.Embed(crossword.Cut{Row: 0, Col: 0, Orientation: crossword.HORIZONTAL, Len: 2}, "ab")
+	isolated.Data[1] = []string{isolated.Stop, isolated.Stop, isolated.Empty}
+
+	buf.Reset()
+	if err := ipuz.Write(&buf, crossword.NewPuzzle(&isolated, nil)); err != nil {
+		t.Fatalf("Expected ipuz.Write() to succeed with empty cells, got %v", err)
+	}
+	if err := json.Unmarshal(buf.Bytes(), &written); err != nil || fmt.Sprint(written.Puzzle) != "[[1 0 #] [# # #]]" {
+		t.Errorf("Expected the empty cell to be written as a block, got %v", written.Puzzle)
+	}
+}
+
+func TestRightToLeft(t *testing.T) {
+	cw := crossword.NewCrossword(3, 3)
+	cw.Direction = crossword.RIGHT_TO_LEFT
+	for row, word := range []crossword.Word{"abc", "def", "ghi"} {
+		cw.Embed(crossword.Cut{Row: row, Col: 0, Orientation: crossword.HORIZONTAL, Len: 3}, word)
+	}
+
+	var buf bytes.Buffer
+	if err := ipuz.Write(&buf, crossword.NewPuzzle(&cw, map[crossword.Word]string{"abc": "First", "adg": "Rightmost"})); err != nil {
+		t.Fatalf("Expected ipuz.Write() to succeed, got %v", err)
+	}
+	written := buf.String()
+
+	// Numbered from the top right cell, and rows written as displayed
+	if !strings.Contains(written, `"`+ipuz.DIRECTION_FIELD+`": "rtl"`) || !strings.Contains(written, `"C",
+      "B",
+      "A"`) {
+		t.Errorf("Expected a right to left grid, got %s", written)
+	}
+
+	// Files written elsewhere have no direction, but are numbered right to left
+	without := strings.Replace(written, ",\n  \""+ipuz.DIRECTION_FIELD+`": "rtl"`, "", 1)
+	if without == written {
+		t.Fatalf("Expected the direction to be the last field, got %s", written)
+	}
+	for name, raw := range map[string]string{"round trip": written, "without a direction": without} {
+		read, err := ipuz.Read(strings.NewReader(raw))
+		if err != nil {
+			t.Fatalf("%s: expected ipuz.Read() to succeed, got %v", name, err)
+		}
+
+		if read.Crossword.Direction != crossword.RIGHT_TO_LEFT || !reflect.DeepEqual(read.Crossword.Data, cw.Data) {
+			t.Errorf("%s: expected the grid read right to left, got %v:\n%s", name, read.Crossword.Direction, read.Crossword.PrintData())
+		}
+		if down := read.Crossword.Number().Down[0]; down.Number != 1 || down.Word != "adg" {
+			t.Errorf("%s: expected Down 1 to be adg, got %+v", name, down)
+		}
+		expected := map[crossword.Word]string{"abc": "First", "def": "", "ghi": "", "adg": "Rightmost", "beh": "", "cfi": ""}
+		if got := cluesByWord(read); !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s: expected clues %v, got %v", name, expected, got)
+		}
+	}
+
+	invalid := strings.Replace(written, `"rtl"`, `"ttb"`, 1)
+	if _, err := ipuz.Read(strings.NewReader(invalid)); err == nil {
+		t.Errorf("Expected an error for an unknown direction")
+	}
+}
+
+func TestRead(t *testing.T) {
+	raw, err := os.ReadFile("testdata/published.ipuz")
+	if err != nil {
+		t.Fatalf("Unable to read fixture: %v", err)
+	}
+
+	puzzle, err := ipuz.Read(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Expected ipuz.Read() to succeed, got %v", err)
+	}
+
+	stop := puzzle.Crossword.Stop
+	if expected := [][]string{{"c", "a", "t"}, {"a", stop, "o"}, {"b", "o", "w"}}; !reflect.DeepEqual(puzzle.Crossword.Data, expected) {
+		t.Errorf("Expected the published grid, got:\n%s", puzzle.Crossword.PrintData())
+	}
+	if len(puzzle.Crossword.Embeddings) != 4 {
+		t.Errorf("Expected every entry to be embedded, got %v", puzzle.Crossword.Embeddings)
+	}
+	// Clues are given as pairs and as objects, under labelled directions
+	expected := map[crossword.Word]string{"cat": "Feline", "cab": "Taxi", "tow": "Pull", "bow": "Ribbon knot"}
+	if got := cluesByWord(puzzle); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected clues %v, got %v", expected, got)
+	}
+	if puzzle.Title != "Test" || puzzle.Author != "Author" || puzzle.Copyright != "© 2026" {
+		t.Errorf("Unexpected metadata %+v", puzzle)
+	}
+
+	invalid := map[string]string{
+		"not a crossword": strings.Replace(string(raw), "crossword#1", "sudoku#1", 1),
+		"missing row":     strings.Replace(string(raw), `"height": 3`, `"height": 4`, 1),
+		"unknown clue":    strings.Replace(string(raw), `["2", "Pull"]`, `["3", "Pull"]`, 1),
+		"malformed":       string(raw[:100]),
+	}
+	for name, raw := range invalid {
+		if _, err := ipuz.Read(strings.NewReader(raw)); err == nil {
+			t.Errorf("%s: expected ipuz.Read() to fail", name)
+		}
+	}
+}
@@ -1,35 +1,36 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
 	"os"
-	"strings"
+	"os/signal"
 	"time"
 
 	"github.com/nitzanhen/crossword/src/crossword"
-	"github.com/nitzanhen/crossword/src/structure"
 )
 
 type BuildResult struct {
 	Result        *crossword.Crossword
 	StartingWords []crossword.Word
+	Seed          int64
 	Width         int
 	Height        int
 	Success       bool
 	Time          float64
 	Calls         int
 	Failures      int
+	Restarts      int
 }
 
 const (
-	BATCH_SIZE  = 50
-	NUM_BATCHES = 50
-	WIDTH       = 5
-	HEIGHT      = 5
-	TIMEOUT     = 10 * time.Second
+	WIDTH        = 5
+	HEIGHT       = 5
+	RESTART_UNIT = 100 // Number of calls in one unit of the restart schedule
 )
 
 func main() {
@@ -40,50 +41,46 @@ func main() {
 
 	runId := rand.Intn(100_000)
 
-	for i := 0; i < NUM_BATCHES; i++ {
-		results := structure.List[BuildResult]{}
-
-		for j := 0; j < BATCH_SIZE; j++ {
-			resultChan := make(chan *crossword.Crossword, 1)
-
-			shuffled := shuffle(words)
-			builder := crossword.NewBuilder(WIDTH, HEIGHT, shuffled, false)
-			// builder.SetListener(func(cw *crossword.Crossword) {
-			// 	fmt.Printf("\033[2;0H")
-			// 	fmt.Printf("\n%s\n\n", cw.PrintData())
-			// })
-
-			startingWords := shuffled[:10]
-
-			fmt.Printf("Iteration %d-%d: \nFirst words: %v\n", i, j, startingWords)
-
-			var start time.Time
-
-			go func() {
-				start = time.Now()
-				resultChan <- builder.Build()
-			}()
-
-			select {
-
-			case res := <-resultChan:
-				elapsed := time.Since(start).Seconds()
-				results.Add(BuildResult{res, startingWords, WIDTH, HEIGHT, true, elapsed, builder.Calls, builder.Failures})
-
-				fmt.Printf("Success in %f seconds:\n%s\n", elapsed, res.PrintData())
-
-			case <-time.After(TIMEOUT):
-				elapsed := time.Since(start).Seconds()
-				results.Add(BuildResult{nil, startingWords, WIDTH, HEIGHT, false, elapsed, builder.Calls, builder.Failures})
-
-				fmt.Println("Timed out.")
-			}
-		}
-
-		writeResult(results.ToSlice(), runId, i)
-
+	// A single seed determines both the word order and the builder's tie-breaking,
+	// so the result can be reproduced from its recorded seed.
+	seed := rand.Int63()
+
+	shuffled := shuffle(rand.New(rand.NewSource(seed)), words)
+	builder := crossword.NewBuilder(WIDTH, HEIGHT, shuffled, false)
+	builder.SetSeed(seed)
+	// The corpus is Hebrew, so words are written right to left
+	builder.SetDirection(crossword.RIGHT_TO_LEFT)
+	// Rather than timing out on an unlucky order, the builder restarts with a different one
+	builder.SetRestarts(crossword.LubyRestarts(RESTART_UNIT))
+	// builder.SetListener(func(cw *crossword.Crossword) {
+	// 	fmt.Printf("\033[2;0H")
+	// 	fmt.Printf("\n%s\n\n", cw.PrintData())
+	// })
+
+	startingWords := shuffled[:10]
+
+	fmt.Printf("Seed %d: \nFirst words: %v\n", seed, startingWords)
+
+	// Restarts keep the build going until it succeeds, unless interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+
+	start := time.Now()
+	res, err := builder.BuildContext(ctx)
+	elapsed := time.Since(start).Seconds()
+
+	stop()
+
+	stats := builder.Stats()
+	writeResult(BuildResult{res, startingWords, seed, WIDTH, HEIGHT, err == nil, elapsed, stats.Calls, stats.Failures, stats.Restarts}, runId)
+
+	switch {
+	case err == nil:
+		fmt.Printf("Success in %f seconds, after %d restarts:\n%s\n", elapsed, stats.Restarts, res.PrintData())
+	case errors.Is(err, context.Canceled):
+		fmt.Println("Interrupted.")
+	default:
+		fmt.Printf("Failed: %v\n", err)
 	}
-
 }
 
 func getWords() []crossword.Word {
@@ -92,30 +89,21 @@ func getWords() []crossword.Word {
 		log.Fatalf("Unable to read file: %v", err)
 	}
 
-	var words []crossword.Word
-	err = json.Unmarshal(raw, &words)
+	var written []string
+	err = json.Unmarshal(raw, &written)
 	if err != nil {
 		log.Fatalf("Unable to unmarshal: %v", err)
 	}
 
-	for i, w := range words {
-		s := string(w)
-		s = strings.ToLower(s)
-		s = strings.ReplaceAll(s, "ף", "פ")
-		s = strings.ReplaceAll(s, "ץ", "צ")
-		s = strings.ReplaceAll(s, "ך", "כ")
-		s = strings.ReplaceAll(s, "ן", "נ")
-		s = strings.ReplaceAll(s, "ם", "מ")
-		words[i] = crossword.Word(s)
-	}
+	words, _ := crossword.NormalizeWords(written, crossword.Hebrew)
 
 	fmt.Printf("Read %d words\n", len(words))
 
 	return words
 }
 
-func shuffle[T any](items []T) []T {
-	perm := rand.Perm(len(items))
+func shuffle[T any](rng *rand.Rand, items []T) []T {
+	perm := rng.Perm(len(items))
 	shuffled := make([]T, len(items))
 
 	for i, j := range perm {
@@ -125,11 +113,11 @@ func shuffle[T any](items []T) []T {
 	return shuffled
 }
 
-func writeResult(results []BuildResult, runId, batchId int) {
-	data, _ := json.MarshalIndent(results, "", "  ")
+func writeResult(result BuildResult, runId int) {
+	data, _ := json.MarshalIndent([]BuildResult{result}, "", "  ")
 
 	os.Mkdir("./output", 0755)
-	filename := fmt.Sprintf("./output/result-%d-%d.json", runId, batchId)
+	filename := fmt.Sprintf("./output/result-%d.json", runId)
 
 	if err := os.WriteFile(filename, data, 0644); err != nil {
 		log.Fatalf("%v", err)
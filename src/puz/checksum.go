@@ -0,0 +1,102 @@
+package puz
+
+import "encoding/binary"
+
+// Masks the low and high bytes of the four component checksums in the header.
+const checksumMask = "ICHEATED"
+
+// Returns the format's checksum of data, continuing from the checksum of whatever preceded it.
+func checksum(data []byte, sum uint16) uint16 {
+	for _, b := range data {
+		if sum&1 == 1 {
+			sum = sum>>1 | 0x8000
+		} else {
+			sum >>= 1
+		}
+		sum += uint16(b)
+	}
+
+	return sum
+}
+
+// The parts of a file the checksums cover.
+type sections struct {
+	cib       []byte // The width, height, number of clues, puzzle type and scrambled tag
+	solution  []byte
+	fill      []byte
+	title     string
+	author    string
+	copyright string
+	clues     []string
+	notes     string
+
+	// Whether the notes are checksummed, as they are from version 1.3 of the format on.
+	checksumNotes bool
+}
+
+// Returns the checksum of the strings, which leaves out empty ones and the clues' terminating nulls.
+func (s *sections) textChecksum(sum uint16) uint16 {
+	for _, field := range []string{s.title, s.author, s.copyright} {
+		if field != "" {
+			sum = checksum(append([]byte(field), 0), sum)
+		}
+	}
+
+	for _, clue := range s.clues {
+		if clue != "" {
+			sum = checksum([]byte(clue), sum)
+		}
+	}
+
+	if s.checksumNotes && s.notes != "" {
+		sum = checksum(append([]byte(s.notes), 0), sum)
+	}
+
+	return sum
+}
+
+// Returns the checksum of the whole file.
+func (s *sections) globalChecksum() uint16 {
+	sum := checksum(s.cib, 0)
+	sum = checksum(s.solution, sum)
+	sum = checksum(s.fill, sum)
+
+	return s.textChecksum(sum)
+}
+
+// Returns the masked checksums of the header's sections, the solution, the fill and the strings,
+// low bytes first and then high bytes.
+func (s *sections) maskedChecksums() [8]byte {
+	sums := []uint16{
+		checksum(s.cib, 0),
+		checksum(s.solution, 0),
+		checksum(s.fill, 0),
+		s.textChecksum(0),
+	}
+
+	var masked [8]byte
+	for i, sum := range sums {
+		masked[i] = checksumMask[i] ^ byte(sum)
+		masked[i+4] = checksumMask[i+4] ^ byte(sum>>8)
+	}
+
+	return masked
+}
+
+// Writes the checksums of s to the header.
+func (s *sections) writeChecksums(header []byte) {
+	binary.LittleEndian.PutUint16(header[0x00:], s.globalChecksum())
+	binary.LittleEndian.PutUint16(header[0x0E:], checksum(s.cib, 0))
+
+	masked := s.maskedChecksums()
+	copy(header[0x10:0x18], masked[:])
+}
+
+// Checks and returns whether the checksums in the header match s.
+func (s *sections) checkChecksums(header []byte) bool {
+	masked := s.maskedChecksums()
+
+	return binary.LittleEndian.Uint16(header[0x00:]) == s.globalChecksum() &&
+		binary.LittleEndian.Uint16(header[0x0E:]) == checksum(s.cib, 0) &&
+		string(header[0x10:0x18]) == string(masked[:])
+}
@@ -0,0 +1,323 @@
+// Reads and writes crosswords in the binary Across Lite (.puz) format.
+package puz
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+)
+
+const (
+	MAGIC   = "ACROSS&DOWN\x00"
+	VERSION = "1.3\x00"
+
+	HEADER_SIZE = 0x34
+
+	BLACK = '.' // Marks black cells in the solution and fill
+	BLANK = '-' // Marks empty cells in the fill
+
+	PUZZLE_TYPE_NORMAL = 0x0001
+	SCRAMBLED_TAG      = 0x0004
+)
+
+// Returned by Read when a checksum does not match the file's contents.
+var ErrChecksum = errors.New("puz: checksum mismatch")
+
+// A crossword along with its clues and metadata, and how its solution is scrambled.
+type Puzzle struct {
+	crossword.Puzzle
+
+	// The key the solution is scrambled with when written, between 1000 and 9999 with no zero digits;
+	// 0 writes it unscrambled.
+	Key int
+	// Whether the crossword read holds a scrambled solution; see Unlock.
+	Scrambled bool
+
+	// The checksum of the unscrambled solution, which Unlock checks keys against.
+	scrambledChecksum uint16
+}
+
+// Creates a puzzle of cw, clueing each of its entries with the clue of its word, if any.
+func NewPuzzle(cw *crossword.Crossword, clues map[crossword.Word]string) *Puzzle {
+	return &Puzzle{Puzzle: *crossword.NewPuzzle(cw, clues)}
+}
+
+// Returns the entries of cw in the format's order: by their numbers, across before down.
+// The format has no right-to-left crosswords, so entries are numbered left to right whatever cw's direction;
+// across entries of a right-to-left crossword then start at their rightmost cell, and solvers read them that way.
+func entries(cw *crossword.Crossword) []crossword.Entry {
+	ltr := *cw
+	ltr.Direction = crossword.LEFT_TO_RIGHT
+
+	return ltr.Number().Entries()
+}
+
+// Encodes s in ISO-8859-1, the format's encoding.
+func encode(s string) (string, error) {
+	encoded := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r > 0xFF {
+			return "", fmt.Errorf("puz: %q cannot be encoded in ISO-8859-1", r)
+		}
+		encoded = append(encoded, byte(r))
+	}
+
+	return string(encoded), nil
+}
+
+// Decodes s from ISO-8859-1.
+func decode(s string) string {
+	runes := make([]rune, len(s))
+	for i := 0; i < len(s); i++ {
+		runes[i] = rune(s[i])
+	}
+
+	return string(runes)
+}
+
+// Writes the puzzle in the .puz format. Letters are written in upper case.
+// Stops and empty cells are written as black cells, and entries numbered accordingly (see crossword.Completed).
+//
+// Returns an error if a cell or string cannot be encoded in ISO-8859-1, or if the solution cannot be scrambled.
+func Write(w io.Writer, puzzle *Puzzle) error {
+	completed := puzzle.Crossword.Completed()
+	cw := &completed
+	if cw.Width > 0xFF || cw.Height > 0xFF {
+		return fmt.Errorf("puz: %dx%d grids are too large", cw.Width, cw.Height)
+	}
+
+	s := sections{checksumNotes: true}
+
+	for row := 0; row < cw.Height; row++ {
+		for col := 0; col < cw.Width; col++ {
+			if cw.Data[row][col] == cw.Stop {
+				s.solution = append(s.solution, BLACK)
+				s.fill = append(s.fill, BLACK)
+				continue
+			}
+
+			letter, err := encode(strings.ToUpper(cw.Data[row][col]))
+			if err != nil {
+				return err
+			}
+			if len(letter) != 1 {
+				return fmt.Errorf("puz: cell (%d, %d) must hold a single letter, got %q", row, col, letter)
+			}
+
+			s.solution = append(s.solution, letter[0])
+			s.fill = append(s.fill, BLANK)
+		}
+	}
+
+	for _, entry := range entries(cw) {
+		clue, err := encode(puzzle.ClueOf(entry.Cut))
+		if err != nil {
+			return err
+		}
+		s.clues = append(s.clues, clue)
+	}
+
+	for _, field := range []struct {
+		value   string
+		encoded *string
+	}{
+		{puzzle.Title, &s.title},
+		{puzzle.Author, &s.author},
+		{puzzle.Copyright, &s.copyright},
+		{puzzle.Notes, &s.notes},
+	} {
+		encoded, err := encode(field.value)
+		if err != nil {
+			return err
+		}
+		*field.encoded = encoded
+	}
+
+	header := make([]byte, HEADER_SIZE)
+	copy(header[0x02:], MAGIC)
+	copy(header[0x18:], VERSION)
+
+	var tag uint16
+	if puzzle.Key != 0 {
+		digits, err := keyDigits(puzzle.Key)
+		if err != nil {
+			return fmt.Errorf("puz: %w", err)
+		}
+
+		binary.LittleEndian.PutUint16(header[0x1E:], checksum(columnLetters(s.solution, cw.Width, cw.Height), 0))
+		if err := scramble(s.solution, cw.Width, cw.Height, digits); err != nil {
+			return fmt.Errorf("puz: %w", err)
+		}
+		tag = SCRAMBLED_TAG
+	}
+
+	header[0x2C] = byte(cw.Width)
+	header[0x2D] = byte(cw.Height)
+	binary.LittleEndian.PutUint16(header[0x2E:], uint16(len(s.clues)))
+	binary.LittleEndian.PutUint16(header[0x30:], PUZZLE_TYPE_NORMAL)
+	binary.LittleEndian.PutUint16(header[0x32:], tag)
+
+	s.cib = header[0x2C:HEADER_SIZE]
+	s.writeChecksums(header)
+
+	var buf bytes.Buffer
+	buf.Write(header)
+	buf.Write(s.solution)
+	buf.Write(s.fill)
+	for _, str := range append([]string{s.title, s.author, s.copyright}, append(s.clues, s.notes)...) {
+		buf.WriteString(str)
+		buf.WriteByte(0)
+	}
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// Reads a puzzle in the .puz format. Letters are read in lower case, and black cells as stops;
+// every entry is embedded in the crossword, and clued in Clues.
+//
+// If the solution is scrambled, Scrambled is set and the crossword holds the scrambled letters until Unlock is called.
+// Returns ErrChecksum if a checksum does not match the contents, and an error if the file is malformed.
+func Read(r io.Reader) (*Puzzle, error) {
+	reader := bufio.NewReader(r)
+
+	header := make([]byte, HEADER_SIZE)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, fmt.Errorf("puz: reading header: %w", err)
+	}
+	if string(header[0x02:0x0E]) != MAGIC {
+		return nil, fmt.Errorf("puz: not an Across Lite file")
+	}
+
+	width, height := int(header[0x2C]), int(header[0x2D])
+	numClues := int(binary.LittleEndian.Uint16(header[0x2E:]))
+	tag := binary.LittleEndian.Uint16(header[0x32:])
+
+	s := sections{
+		cib:           header[0x2C:HEADER_SIZE],
+		checksumNotes: string(header[0x18:0x1B]) >= VERSION[:3],
+	}
+	s.solution = make([]byte, width*height)
+	s.fill = make([]byte, width*height)
+	if _, err := io.ReadFull(reader, s.solution); err != nil {
+		return nil, fmt.Errorf("puz: reading solution: %w", err)
+	}
+	if _, err := io.ReadFull(reader, s.fill); err != nil {
+		return nil, fmt.Errorf("puz: reading fill: %w", err)
+	}
+
+	readString := func() (string, error) {
+		str, err := reader.ReadString(0)
+		if err != nil {
+			return "", fmt.Errorf("puz: reading strings: %w", err)
+		}
+
+		return strings.TrimSuffix(str, "\x00"), nil
+	}
+
+	var err error
+	for _, field := range []*string{&s.title, &s.author, &s.copyright} {
+		if *field, err = readString(); err != nil {
+			return nil, err
+		}
+	}
+	s.clues = make([]string, numClues)
+	for i := range s.clues {
+		if s.clues[i], err = readString(); err != nil {
+			return nil, err
+		}
+	}
+	if s.notes, err = readString(); err != nil {
+		return nil, err
+	}
+
+	if !s.checkChecksums(header) {
+		return nil, ErrChecksum
+	}
+
+	cw := crossword.NewCrossword(width, height)
+	for row := 0; row < height; row++ {
+		for col := 0; col < width; col++ {
+			if b := s.solution[row*width+col]; b == BLACK {
+				cw.Data[row][col] = cw.Stop
+			} else {
+				cw.Data[row][col] = strings.ToLower(decode(string(b)))
+			}
+		}
+	}
+
+	puzzle := &Puzzle{
+		Puzzle: crossword.Puzzle{
+			Crossword: &cw,
+			Title:     decode(s.title),
+			Author:    decode(s.author),
+			Copyright: decode(s.copyright),
+			Notes:     decode(s.notes),
+		},
+
+		Scrambled:         tag&SCRAMBLED_TAG != 0,
+		scrambledChecksum: binary.LittleEndian.Uint16(header[0x1E:]),
+	}
+
+	numbered := entries(&cw)
+	if len(numbered) != numClues {
+		return nil, fmt.Errorf("puz: the grid has %d entries, but there are %d clues", len(numbered), numClues)
+	}
+
+	for i, entry := range numbered {
+		puzzle.Clues = append(puzzle.Clues, crossword.Clue{Cut: entry.Cut, Text: decode(s.clues[i])})
+	}
+	if !puzzle.Scrambled {
+		cw.EmbedEntries()
+	}
+
+	return puzzle, nil
+}
+
+// Unscrambles the solution of a puzzle read with a scrambled one, using the key it was scrambled with.
+// Returns an error, leaving the puzzle unchanged, if the key is wrong.
+func (puzzle *Puzzle) Unlock(key int) error {
+	if !puzzle.Scrambled {
+		return nil
+	}
+
+	digits, err := keyDigits(key)
+	if err != nil {
+		return fmt.Errorf("puz: %w", err)
+	}
+
+	cw := puzzle.Crossword
+	solution := make([]byte, 0, cw.Width*cw.Height)
+	for row := 0; row < cw.Height; row++ {
+		for col := 0; col < cw.Width; col++ {
+			if cw.Data[row][col] == cw.Stop {
+				solution = append(solution, BLACK)
+			} else {
+				solution = append(solution, strings.ToUpper(cw.Data[row][col])[0])
+			}
+		}
+	}
+
+	unscramble(solution, cw.Width, cw.Height, digits)
+	if checksum(columnLetters(solution, cw.Width, cw.Height), 0) != puzzle.scrambledChecksum {
+		return fmt.Errorf("puz: wrong key %d", key)
+	}
+
+	for i, b := range solution {
+		if b != BLACK {
+			cw.Data[i/cw.Width][i%cw.Width] = strings.ToLower(string(rune(b)))
+		}
+	}
+
+	puzzle.Scrambled = false
+	puzzle.Key = key
+	cw.EmbedEntries()
+
+	return nil
+}
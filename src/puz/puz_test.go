@@ -0,0 +1,173 @@
+package puz_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+	"github.com/nitzanhen/crossword/src/puz"
+)
+
+// Returns a crossword of the rows, in which "." marks stops and " " empty cells, with every complete entry embedded.
+func grid(rows ...string) *crossword.Crossword {
+	cw := crossword.NewCrossword(len([]rune(rows[0])), len(rows))
+	for i, row := range rows {
+		for j, letter := range []rune(row) {
+			switch letter {
+			case '.':
+				cw.Data[i][j] = cw.Stop
+			case ' ':
+				cw.Data[i][j] = cw.Empty
+			default:
+				cw.Data[i][j] = string(letter)
+			}
+		}
+	}
+	cw.EmbedEntries()
+
+	return &cw
+}
+
+// The grid of the fixtures
+var rows = []string{"cat", "a.o", "bow"}
+
+func readFixture(t *testing.T, name string) []byte {
+	raw, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("Unable to read fixture: %v", err)
+	}
+
+	return raw
+}
+
+func newPuzzle(cw *crossword.Crossword, clues map[crossword.Word]string) *puz.Puzzle {
+	puzzle := puz.NewPuzzle(cw, clues)
+	puzzle.Title = "Test"
+	puzzle.Author = "Author"
+	puzzle.Copyright = "© 2026"
+	puzzle.Notes = "Notes"
+
+	return puzzle
+}
+
+func TestWrite(t *testing.T) {
+	clues := map[crossword.Word]string{"cat": "Feline", "cab": "Taxi", "tow": "Pull", "bow": "Ribbon knot"}
+
+	for name, key := range map[string]int{"plain.puz": 0, "scrambled.puz": 1234} {
+		puzzle := newPuzzle(grid(rows...), clues)
+		puzzle.Key = key
+
+		var buf bytes.Buffer
+		if err := puz.Write(&buf, puzzle); err != nil {
+			t.Fatalf("%s: expected puz.Write() to succeed, got %v", name, err)
+		}
+
+		if expected := readFixture(t, name); !bytes.Equal(buf.Bytes(), expected) {
+			t.Errorf("%s: expected\n%x\ngot\n%x", name, expected, buf.Bytes())
+		}
+	}
+
+	// Entries are numbered left to right either way, so the mirrored clues land on the same cuts
+	rtl := grid(rows...)
+	rtl.Direction = crossword.RIGHT_TO_LEFT
+	rtl.EmbedEntries()
+
+	var buf bytes.Buffer
+	mirrored := map[crossword.Word]string{"tac": "Feline", "cab": "Taxi", "tow": "Pull", "wob": "Ribbon knot"}
+	if err := puz.Write(&buf, newPuzzle(rtl, mirrored)); err != nil || !bytes.Equal(buf.Bytes(), readFixture(t, "plain.puz")) {
+		t.Errorf("Expected a right to left crossword to be written left to right, got %v", err)
+	}
+}
+
+func TestRead(t *testing.T) {
+	expected := grid(rows...)
+
+	for _, name := range []string{"plain.puz", "scrambled.puz"} {
+		puzzle, err := puz.Read(bytes.NewReader(readFixture(t, name)))
+		if err != nil {
+			t.Fatalf("%s: expected puz.Read() to succeed, got %v", name, err)
+		}
+
+		if puzzle.Scrambled != (name == "scrambled.puz") {
+			t.Errorf("%s: expected Scrambled = %v", name, !puzzle.Scrambled)
+		}
+		if puzzle.Scrambled {
+			if err := puzzle.Unlock(4321); err == nil {
+				t.Errorf("%s: expected Unlock() to fail with the wrong key", name)
+			}
+			if err := puzzle.Unlock(1234); err != nil {
+				t.Fatalf("%s: expected Unlock() to succeed, got %v", name, err)
+			}
+		}
+
+		if !reflect.DeepEqual(puzzle.Crossword.Data, expected.Data) {
+			t.Errorf("%s: expected:\n%s\ngot:\n%s", name, expected.PrintData(), puzzle.Crossword.PrintData())
+		}
+		if puzzle.Title != "Test" || puzzle.Author != "Author" || puzzle.Copyright != "© 2026" || puzzle.Notes != "Notes" {
+			t.Errorf("%s: unexpected metadata %+v", name, puzzle)
+		}
+
+		clues := map[crossword.Word]string{}
+		for _, clue := range puzzle.Clues {
+			for _, cutword := range puzzle.Crossword.Embeddings {
+				if cutword.Cut == clue.Cut {
+					clues[cutword.Word] = clue.Text
+				}
+			}
+		}
+		for _, cutword := range expected.Embeddings {
+			if !puzzle.Crossword.IsWordEmbedded(cutword.Word) {
+				t.Errorf("%s: expected %v to be embedded", name, cutword.Word)
+			}
+		}
+		if clues["cab"] != "Taxi" || clues["bow"] != "Ribbon knot" {
+			t.Errorf("%s: expected clues to match their entries, got %v", name, puzzle.Clues)
+		}
+
+		// Reading and writing again gives the same file
+		var buf bytes.Buffer
+		if err := puz.Write(&buf, puzzle); err != nil || !bytes.Equal(buf.Bytes(), readFixture(t, name)) {
+			t.Errorf("%s: expected the puzzle to round trip, got %v", name, err)
+		}
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	corrupt := readFixture(t, "plain.puz")
+	corrupt[len(corrupt)-3] ^= 0xFF
+	if _, err := puz.Read(bytes.NewReader(corrupt)); !errors.Is(err, puz.ErrChecksum) {
+		t.Errorf("Expected ErrChecksum for a corrupt file, got %v", err)
+	}
+
+	if _, err := puz.Read(bytes.NewReader(readFixture(t, "plain.puz")[:40])); err == nil {
+		t.Errorf("Expected an error for a truncated file")
+	}
+
+	if err := puz.Write(&bytes.Buffer{}, puz.NewPuzzle(grid("שלום", "a..b"), nil)); err == nil {
+		t.Errorf("Expected an error for letters outside of ISO-8859-1")
+	}
+}
+
+func TestWriteEmptyCells(t *testing.T) {
+	// Builders leave the cells no word covers empty, like the last one here
+	cw := grid("ab.", "c..", "d. ")
+
+	var buf bytes.Buffer
+	if err := puz.Write(&buf, puz.NewPuzzle(cw, map[crossword.Word]string{"ab": "First", "acd": "Down"})); err != nil {
+		t.Fatalf("Expected puz.Write() to succeed, got %v", err)
+	}
+
+	puzzle, err := puz.Read(&buf)
+	if err != nil {
+		t.Fatalf("Expected puz.Read() to succeed, got %v", err)
+	}
+	if data := puzzle.Crossword.Data; data[2][2] != puzzle.Crossword.Stop {
+		t.Errorf("Expected the empty cell to be written as a black cell, got:\n%s", puzzle.Crossword.PrintData())
+	}
+	if len(puzzle.Clues) != 2 || puzzle.Clues[0].Text != "First" || puzzle.Clues[1].Text != "Down" {
+		t.Errorf("Expected the clues of ab and acd, got %v", puzzle.Clues)
+	}
+}
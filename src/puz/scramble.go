@@ -0,0 +1,128 @@
+package puz
+
+import "fmt"
+
+// Returns the four digits of a scrambling key, which must be between 1000 and 9999 and have no zeros.
+func keyDigits(key int) ([4]int, error) {
+	var digits [4]int
+	if key < 1000 || key > 9999 {
+		return digits, fmt.Errorf("invalid key %d: must have four digits", key)
+	}
+
+	for i := 3; i >= 0; i-- {
+		digits[i] = key % 10
+		key /= 10
+
+		if digits[i] == 0 {
+			return digits, fmt.Errorf("invalid key: digits must not be 0")
+		}
+	}
+
+	return digits, nil
+}
+
+// Returns the solution's letters column by column, leaving out black cells.
+func columnLetters(solution []byte, width, height int) []byte {
+	letters := []byte{}
+	for col := 0; col < width; col++ {
+		for row := 0; row < height; row++ {
+			if b := solution[row*width+col]; b != BLACK {
+				letters = append(letters, b)
+			}
+		}
+	}
+
+	return letters
+}
+
+// Writes letters back to the solution's letter cells, column by column.
+func setColumnLetters(solution []byte, width, height int, letters []byte) {
+	k := 0
+	for col := 0; col < width; col++ {
+		for row := 0; row < height; row++ {
+			if i := row*width + col; solution[i] != BLACK {
+				solution[i] = letters[k]
+				k++
+			}
+		}
+	}
+}
+
+// Shifts each letter (A to Z) forward by the key's digits in turn, or backward if sign is -1.
+func shift(letters []byte, digits [4]int, sign int) {
+	for i, b := range letters {
+		letters[i] = byte('A' + ((int(b-'A')+sign*digits[i%4])%26+26)%26)
+	}
+}
+
+// Moves the first k letters to the end, unless there are no more than k letters.
+func rotate(letters []byte, k int) []byte {
+	if k >= len(letters) {
+		return letters
+	}
+
+	return append(letters[k:], letters[:k]...)
+}
+
+// Interleaves the second half of letters with the first, starting from the second.
+func shuffle(letters []byte) []byte {
+	mid := len(letters) / 2
+	shuffled := make([]byte, 0, len(letters))
+	for i := 0; i < mid; i++ {
+		shuffled = append(shuffled, letters[mid+i], letters[i])
+	}
+	if len(letters)%2 == 1 {
+		shuffled = append(shuffled, letters[len(letters)-1])
+	}
+
+	return shuffled
+}
+
+// Reverses shuffle.
+func unshuffle(letters []byte) []byte {
+	unshuffled := make([]byte, 0, len(letters))
+	for i := 1; i < len(letters); i += 2 {
+		unshuffled = append(unshuffled, letters[i])
+	}
+	for i := 0; i < len(letters); i += 2 {
+		unshuffled = append(unshuffled, letters[i])
+	}
+
+	return unshuffled
+}
+
+// Scrambles the letters of the solution in place with the key, as Across Lite does for locked puzzles.
+// Only solutions of the letters A to Z can be scrambled.
+func scramble(solution []byte, width, height int, digits [4]int) error {
+	letters := columnLetters(solution, width, height)
+	for _, b := range letters {
+		if b < 'A' || b > 'Z' {
+			return fmt.Errorf("cannot scramble %q: only the letters A to Z can be scrambled", b)
+		}
+	}
+
+	for _, digit := range digits {
+		shift(letters, digits, 1)
+		letters = rotate(letters, digit)
+		letters = shuffle(letters)
+	}
+
+	setColumnLetters(solution, width, height, letters)
+
+	return nil
+}
+
+// Reverses scramble.
+func unscramble(solution []byte, width, height int, digits [4]int) {
+	letters := columnLetters(solution, width, height)
+
+	for i := 3; i >= 0; i-- {
+		letters = unshuffle(letters)
+		if digits[i] < len(letters) {
+			letters = rotate(letters, len(letters)-digits[i])
+		}
+		shift(letters, digits, -1)
+	}
+
+	setColumnLetters(solution, width, height, letters)
+}
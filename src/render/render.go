@@ -0,0 +1,196 @@
+// Renders crosswords as SVG images and standalone HTML pages, for print and publishing.
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+)
+
+const (
+	DEFAULT_CELL_SIZE = 40 // In pixels
+
+	STOP_COLOR = "#000000"
+	CELL_COLOR = "#ffffff"
+	LINE_COLOR = "#000000"
+
+	DEFAULT_ACROSS_HEADING = "Across"
+	DEFAULT_DOWN_HEADING   = "Down"
+)
+
+// Determines what is rendered and how.
+type Options struct {
+	// Whether to render the letters of the solution, rather than an empty grid to solve.
+	Solution bool
+	// Whether the crossword is complete, so that its empty cells are drawn and numbered as stops,
+	// as it is published (see crossword.Completed). Leave unset to render a grid being filled in.
+	// HTML always renders the puzzle as complete.
+	Complete bool
+	// The side of each cell, in pixels; DEFAULT_CELL_SIZE if 0.
+	CellSize int
+
+	// The page's title; the puzzle's if empty. Only used by HTML.
+	Title string
+	// The headings of the across and down clues, in the puzzle's language;
+	// DEFAULT_ACROSS_HEADING and DEFAULT_DOWN_HEADING if empty. Only used by HTML.
+	AcrossHeading string
+	DownHeading   string
+}
+
+func (options *Options) cellSize() int {
+	if options.CellSize <= 0 {
+		return DEFAULT_CELL_SIZE
+	}
+
+	return options.CellSize
+}
+
+// Returns the value if it is set, or the default otherwise.
+func orDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
+
+// Writes the crossword's grid as an SVG image: stops as black squares, and every other cell as a white one,
+// with the number of the entries starting at it in its corner, and its letter if the solution is rendered.
+// Cells are drawn as they are held in the crossword's data; numbers go in the corner its words are read from.
+func SVG(w io.Writer, cw *crossword.Crossword, options Options) error {
+	if options.Complete {
+		completed := cw.Completed()
+		cw = &completed
+	}
+
+	size := options.cellSize()
+	width, height := cw.Width*size, cw.Height*size
+	numbers := cw.Number().Numbers
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n",
+		width+2, height+2, width+2, height+2,
+	)
+
+	for row := 0; row < cw.Height; row++ {
+		for col := 0; col < cw.Width; col++ {
+			x, y := 1+col*size, 1+row*size
+			value := cw.Data[row][col]
+
+			fill := CELL_COLOR
+			if value == cw.Stop {
+				fill = STOP_COLOR
+			}
+			fmt.Fprintf(&buf,
+				`  <rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="%s"/>`+"\n",
+				x, y, size, size, fill, LINE_COLOR,
+			)
+
+			if value == cw.Stop {
+				continue
+			}
+
+			if number := numbers[row][col]; number > 0 {
+				numberX, anchor := x+size/20+1, "start"
+				if cw.Direction == crossword.RIGHT_TO_LEFT {
+					numberX, anchor = x+size-size/20-1, "end"
+				}
+				fmt.Fprintf(&buf,
+					`  <text x="%d" y="%d" font-size="%d" text-anchor="%s">%d</text>`+"\n",
+					numberX, y+size/4+1, size/4, anchor, number,
+				)
+			}
+
+			if options.Solution && value != cw.Empty {
+				fmt.Fprintf(&buf,
+					`  <text x="%d" y="%d" font-size="%d" text-anchor="middle">%s</text>`+"\n",
+					x+size/2, y+size*3/4, size/2, html.EscapeString(strings.ToUpper(value)),
+				)
+			}
+		}
+	}
+
+	buf.WriteString("</svg>\n")
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// A clue as listed on the page.
+type listedClue struct {
+	Number int
+	Text   string
+}
+
+var page = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html dir="{{.Dir}}">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+.clues { display: flex; gap: 3em; }
+ol { list-style: none; padding: 0; }
+li span { font-weight: bold; margin-inline-end: 0.5em; }
+@media print { body { margin: 0; } }
+</style>
+</head>
+<body>
+{{if .Title}}<h1>{{.Title}}</h1>
+{{end}}{{.Grid}}
+<div class="clues">
+{{range .Lists}}<section>
+<h2>{{.Heading}}</h2>
+<ol>
+{{range .Clues}}<li><span>{{.Number}}</span>{{.Text}}</li>
+{{end}}</ol>
+</section>
+{{end}}</div>
+</body>
+</html>
+`))
+
+// Writes a standalone, printable HTML page of the puzzle: its grid as SVG, followed by its across and down clues.
+// The grid is rendered as published, complete (see Options.Complete); entries without a clue are listed by number alone.
+func HTML(w io.Writer, puzzle *crossword.Puzzle, options Options) error {
+	completed := puzzle.Crossword.Completed()
+	cw := &completed
+
+	var grid bytes.Buffer
+	if err := SVG(&grid, cw, options); err != nil {
+		return err
+	}
+
+	listed := func(entries []crossword.Entry) []listedClue {
+		return crossword.Map(entries, func(entry crossword.Entry) listedClue {
+			return listedClue{entry.Number, puzzle.ClueOf(entry.Cut)}
+		})
+	}
+
+	numbering := cw.Number()
+	lists := []struct {
+		Heading string
+		Clues   []listedClue
+	}{
+		{orDefault(options.AcrossHeading, DEFAULT_ACROSS_HEADING), listed(numbering.Across)},
+		{orDefault(options.DownHeading, DEFAULT_DOWN_HEADING), listed(numbering.Down)},
+	}
+
+	return page.Execute(w, struct {
+		Dir   string
+		Title string
+		Grid  template.HTML
+		Lists any
+	}{
+		cw.Direction.String(),
+		orDefault(options.Title, puzzle.Title),
+		template.HTML(grid.String()),
+		lists,
+	})
+}
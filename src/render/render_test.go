@@ -0,0 +1,155 @@
+package render_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/nitzanhen/crossword/src/crossword"
+	"github.com/nitzanhen/crossword/src/render"
+)
+
+// A grid being filled in: its second row holds empty cells, and its last column a single open cell.
+func partial(direction crossword.Direction) *crossword.Crossword {
+	cw := crossword.NewCrossword(3, 2)
+	cw.Direction = direction
+	cw.Data[0] = []string{"a", "b", cw.Stop}
+	cw.Data[1] = []string{"c", cw.Empty, cw.Empty}
+	cw.EmbedEntries()
+
+	return &cw
+}
+
+// Returns the text of the SVG's text elements, failing if it is not well formed.
+func texts(t *testing.T, svg string) []string {
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	texts := []string{}
+
+	inText := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return texts
+		}
+		if err != nil {
+			t.Fatalf("Expected well formed SVG, got %v:\n%s", err, svg)
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			inText = token.Name.Local == "text"
+		case xml.CharData:
+			if inText {
+				texts = append(texts, string(token))
+			}
+		case xml.EndElement:
+			inText = false
+		}
+	}
+}
+
+func TestSVG(t *testing.T) {
+	tests := []struct {
+		direction crossword.Direction
+		options   render.Options
+		texts     []string
+	}{
+		{crossword.LEFT_TO_RIGHT, render.Options{}, []string{"1", "2", "3"}},
+		{crossword.LEFT_TO_RIGHT, render.Options{Solution: true}, []string{"1", "A", "2", "B", "3", "C"}},
+		// Right to left, the entries of the first row start at its middle cell, and that of the second at its empty end
+		{crossword.RIGHT_TO_LEFT, render.Options{}, []string{"2", "1", "3"}},
+		{crossword.RIGHT_TO_LEFT, render.Options{Solution: true}, []string{"2", "A", "1", "B", "C", "3"}},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		if err := render.SVG(&buf, partial(test.direction), test.options); err != nil {
+			t.Fatalf("%+v: expected render.SVG() to succeed, got %v", test.options, err)
+		}
+		svg := buf.String()
+
+		if got := texts(t, svg); strings.Join(got, " ") != strings.Join(test.texts, " ") {
+			t.Errorf("%+v: expected texts %v, got %v", test.options, test.texts, got)
+		}
+		if rects, stops := strings.Count(svg, "<rect"), strings.Count(svg, render.STOP_COLOR+`" stroke`); rects != 6 || stops != 1 {
+			t.Errorf("%+v: expected 6 cells of which 1 stop, got %d and %d", test.options, rects, stops)
+		}
+		if rtl := strings.Contains(svg, `text-anchor="end"`); rtl != (test.direction == crossword.RIGHT_TO_LEFT) {
+			t.Errorf("%+v: expected numbers in the reading direction's corner:\n%s", test.options, svg)
+		}
+	}
+
+	// Complete, the second row's empty cells are stops, and it holds no entry
+	var buf bytes.Buffer
+	if err := render.SVG(&buf, partial(crossword.LEFT_TO_RIGHT), render.Options{Complete: true}); err != nil {
+		t.Fatalf("Expected render.SVG() to succeed, got %v", err)
+	}
+	svg := buf.String()
+	if got := texts(t, svg); strings.Join(got, " ") != "1" || strings.Count(svg, render.STOP_COLOR+`" stroke`) != 3 {
+		t.Errorf("Expected a complete grid to draw its empty cells as stops, got:\n%s", svg)
+	}
+}
+
+func TestHTML(t *testing.T) {
+	puzzle := crossword.NewPuzzle(partial(crossword.RIGHT_TO_LEFT), map[crossword.Word]string{"ba": "Degree", "ac": "<Cooling>"})
+	puzzle.Title = "Work & Progress"
+	options := render.Options{AcrossHeading: "מאוזן", DownHeading: "מאונך"}
+
+	var buf bytes.Buffer
+	if err := render.HTML(&buf, puzzle, options); err != nil {
+		t.Fatalf("Expected render.HTML() to succeed, got %v", err)
+	}
+	page := buf.String()
+
+	for _, expected := range []string{
+		`<html dir="rtl">`,
+		"<title>Work &amp; Progress</title>",
+		"<svg",
+		"<h2>מאוזן</h2>",
+		"<h2>מאונך</h2>",
+		"<li><span>1</span>Degree</li>",
+		"<li><span>2</span>&lt;Cooling&gt;</li>",
+	} {
+		if !strings.Contains(page, expected) {
+			t.Errorf("Expected the page to contain %q, got:\n%s", expected, page)
+		}
+	}
+	if strings.Contains(page, ">A<") || strings.Contains(page, "Across") {
+		t.Errorf("Expected an empty grid under the given headings, got:\n%s", page)
+	}
+	// The empty cells are published as stops, so the second row holds no entry
+	if stops := strings.Count(page, render.STOP_COLOR+`" stroke`); stops != 3 || strings.Contains(page, "<span>3</span>") {
+		t.Errorf("Expected the empty cells to be drawn as stops, got:\n%s", page)
+	}
+}
+
+func TestHTMLDuplicateWords(t *testing.T) {
+	cw := crossword.NewCrossword(2, 3)
+	cw.Data[0] = []string{"a", "b"}
+	cw.Data[1] = []string{cw.Stop, cw.Stop}
+	cw.Data[2] = []string{"a", "b"}
+
+	// Each entry is clued by its own cut, though both hold the same word
+	puzzle := &crossword.Puzzle{Crossword: &cw, Clues: []crossword.Clue{
+		{Cut: crossword.Cut{Row: 0, Col: 0, Orientation: crossword.HORIZONTAL, Len: 2}, Text: "First"},
+		{Cut: crossword.Cut{Row: 2, Col: 0, Orientation: crossword.HORIZONTAL, Len: 2}, Text: "Second"},
+	}}
+
+	var buf bytes.Buffer
+	if err := render.HTML(&buf, puzzle, render.Options{}); err != nil {
+		t.Fatalf("Expected render.HTML() to succeed, got %v", err)
+	}
+	page := buf.String()
+
+	for _, expected := range []string{
+		"<h2>" + render.DEFAULT_ACROSS_HEADING + "</h2>",
+		"<li><span>1</span>First</li>",
+		"<li><span>2</span>Second</li>",
+	} {
+		if !strings.Contains(page, expected) {
+			t.Errorf("Expected the page to contain %q, got:\n%s", expected, page)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package structure
+
+import "math/bits"
+
+// A set of integers in the range [0, cap), stored as one bit per possible member.
+type Bitset struct {
+	blocks []uint64
+	cap    int
+}
+
+func NewBitset(cap int) Bitset {
+	return Bitset{make([]uint64, (cap+63)/64), cap}
+}
+
+// Returns the number of possible members, i.e. the upper bound given to NewBitset.
+func (b *Bitset) Cap() int {
+	return b.cap
+}
+
+// Returns the number of members in the set.
+func (b *Bitset) Size() int {
+	size := 0
+	for _, block := range b.blocks {
+		size += bits.OnesCount64(block)
+	}
+
+	return size
+}
+
+// Adds an element to the set. Returns the set.
+func (b *Bitset) Add(element int) *Bitset {
+	b.blocks[element/64] |= 1 << (element % 64)
+
+	return b
+}
+
+// Deletes an element from the set. Returns the set.
+func (b *Bitset) Delete(element int) *Bitset {
+	b.blocks[element/64] &^= 1 << (element % 64)
+
+	return b
+}
+
+// Checks and returns whether the set has the given element
+func (b *Bitset) Has(element int) bool {
+	if element < 0 || element >= b.cap {
+		return false
+	}
+
+	return b.blocks[element/64]&(1<<(element%64)) != 0
+}
+
+// Adds all of the other set's elements to the current set.
+// Both sets must have the same Cap.
+func (b *Bitset) Union(other *Bitset) *Bitset {
+	for i := range b.blocks {
+		b.blocks[i] |= other.blocks[i]
+	}
+
+	return b
+}
+
+// Removes from this set all the elements that are not in the other set.
+// Both sets must have the same Cap.
+func (b *Bitset) Intersect(other *Bitset) *Bitset {
+	for i := range b.blocks {
+		b.blocks[i] &= other.blocks[i]
+	}
+
+	return b
+}
+
+// Creates and returns a new copy of the current set.
+func (b *Bitset) Copy() *Bitset {
+	blocks := make([]uint64, len(b.blocks))
+	copy(blocks, b.blocks)
+
+	return &Bitset{blocks, b.cap}
+}
+
+// Returns the members of the set in increasing order.
+func (b *Bitset) ToSlice() []int {
+	slice := make([]int, 0, b.Size())
+
+	for i, block := range b.blocks {
+		for block != 0 {
+			slice = append(slice, i*64+bits.TrailingZeros64(block))
+			block &= block - 1
+		}
+	}
+
+	return slice
+}
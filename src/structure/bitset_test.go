@@ -0,0 +1,48 @@
+package structure_test
+
+import (
+	"testing"
+
+	"github.com/nitzanhen/crossword/src/structure"
+)
+
+func TestBitset(t *testing.T) {
+	set := structure.NewBitset(130)
+
+	set.Add(0).Add(64).Add(129)
+
+	if size := set.Size(); size != 3 {
+		t.Errorf("Expected set.Size() = 3, got %d", size)
+	}
+	if has := set.Has(64); !has {
+		t.Errorf("Expected set.Has(64) = true, got %t", has)
+	}
+	if has := set.Has(130); has {
+		t.Errorf("Expected set.Has(130) = false, got %t", has)
+	}
+
+	set.Delete(64)
+	if has := set.Has(64); has {
+		t.Errorf("Expected set.Has(64) = false, got %t", has)
+	}
+
+	if slice := set.ToSlice(); len(slice) != 2 || slice[0] != 0 || slice[1] != 129 {
+		t.Errorf("Expected set.ToSlice() = [0 129], got %v", slice)
+	}
+
+	other := structure.NewBitset(130)
+	other.Add(1).Add(129)
+
+	intersection := set.Copy().Intersect(&other)
+	if slice := intersection.ToSlice(); len(slice) != 1 || slice[0] != 129 {
+		t.Errorf("Expected intersection = [129], got %v", slice)
+	}
+	if size := set.Size(); size != 2 {
+		t.Errorf("Expected the original set to be unchanged by its copy, got size %d", size)
+	}
+
+	set.Union(&other)
+	if slice := set.ToSlice(); len(slice) != 3 || slice[0] != 0 || slice[1] != 1 || slice[2] != 129 {
+		t.Errorf("Expected union = [0 1 129], got %v", slice)
+	}
+}
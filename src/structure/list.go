@@ -39,6 +39,19 @@ func (list *List[T]) Add(value T) {
 	list.size++
 }
 
+// Removes and returns the value at the start of the list. The list must not be empty.
+func (list *List[T]) Shift() T {
+	head := list.head
+
+	list.head = head.next
+	if list.head == nil {
+		list.tail = nil
+	}
+	list.size--
+
+	return head.value
+}
+
 func (list *List[T]) ToSlice() []T {
 	slice := make([]T, list.size)
 
@@ -21,4 +21,18 @@ func TestList(t *testing.T) {
 	if len(slice) != 3 || slice[0] != 1 || slice[1] != 2 || slice[2] != 3 {
 		t.Errorf("list.ToSlice() = %v, expected [1, 2, 3]", slice)
 	}
+
+	if value := list.Shift(); value != 1 {
+		t.Errorf("list.Shift() = %d; want 1", value)
+	}
+	list.Shift()
+	list.Shift()
+	if size := list.Size(); size != 0 {
+		t.Errorf("list.Size() = %d after shifting every value; want 0", size)
+	}
+
+	list.Add(4)
+	if slice := list.ToSlice(); len(slice) != 1 || slice[0] != 4 {
+		t.Errorf("list.ToSlice() = %v, expected [4]", slice)
+	}
 }
@@ -0,0 +1,59 @@
+package structure
+
+// A map that holds at most a fixed number of entries.
+// When full, setting a new key evicts the least recently used entry.
+type LRU[K comparable, V any] struct {
+	entries *OrderedMap[K, V] // Ordered from least to most recently used
+	cap     int
+}
+
+// Creates an LRU holding at most cap entries. An LRU with cap <= 0 holds nothing.
+func NewLRU[K comparable, V any](cap int) *LRU[K, V] {
+	if cap < 0 {
+		cap = 0
+	}
+
+	return &LRU[K, V]{NewOrderedMap[K, V](cap), cap}
+}
+
+func (c *LRU[K, V]) Size() int {
+	return c.entries.Size()
+}
+
+func (c *LRU[K, V]) Cap() int {
+	return c.cap
+}
+
+// Returns the value stored for key, and whether there is one.
+// A found key becomes the most recently used.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	if !c.entries.Has(key) {
+		var zero V
+		return zero, false
+	}
+
+	value := c.entries.Get(key)
+	c.touch(key, value)
+
+	return value, true
+}
+
+// Stores value for key as the most recently used entry, evicting the least recently used one if needed.
+func (c *LRU[K, V]) Set(key K, value V) {
+	if c.cap <= 0 {
+		return
+	}
+
+	if !c.entries.Has(key) && c.entries.Size() >= c.cap {
+		oldest, _ := c.entries.First()
+		c.entries.Delete(oldest)
+	}
+
+	c.touch(key, value)
+}
+
+// Moves key to the end of the entries, i.e. makes it the most recently used
+func (c *LRU[K, V]) touch(key K, value V) {
+	c.entries.Delete(key)
+	c.entries.Set(key, value)
+}
@@ -0,0 +1,45 @@
+package structure_test
+
+import (
+	"testing"
+
+	"github.com/nitzanhen/crossword/src/structure"
+)
+
+func TestLRU(t *testing.T) {
+	cache := structure.NewLRU[string, int](2)
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+
+	if value, ok := cache.Get("a"); !ok || value != 1 {
+		t.Errorf("Expected cache.Get(\"a\") = (1, true), got (%d, %t)", value, ok)
+	}
+
+	// "b" is now the least recently used
+	cache.Set("c", 3)
+
+	if size := cache.Size(); size != 2 {
+		t.Errorf("Expected cache.Size() = 2, got %d", size)
+	}
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("Expected \"b\" to be evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Errorf("Expected \"a\" to be kept")
+	}
+
+	cache.Set("c", 4)
+	if value, _ := cache.Get("c"); value != 4 {
+		t.Errorf("Expected cache.Get(\"c\") = 4, got %d", value)
+	}
+	if size := cache.Size(); size != 2 {
+		t.Errorf("Expected cache.Size() = 2 after updating a key, got %d", size)
+	}
+
+	empty := structure.NewLRU[string, int](0)
+	empty.Set("a", 1)
+	if _, ok := empty.Get("a"); ok {
+		t.Errorf("Expected an LRU with cap 0 to hold nothing")
+	}
+}
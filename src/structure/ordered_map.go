@@ -79,6 +79,16 @@ func (m *OrderedMap[K, V]) Delete(key K) {
 	}
 }
 
+// Returns the earliest inserted key still in the map, and false if the map is empty.
+func (m *OrderedMap[K, V]) First() (K, bool) {
+	if m.first == nil {
+		var zero K
+		return zero, false
+	}
+
+	return *m.first, true
+}
+
 func (m *OrderedMap[K, V]) IterateEntries(fn func(K, V)) {
 
 	for k := m.first; k != nil; {